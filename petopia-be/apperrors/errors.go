@@ -0,0 +1,137 @@
+package apperrors
+
+import (
+	"errors"
+)
+
+// Sentinel error kinds shared by repositories, DAOs, services and controllers.
+// Check them with errors.Is; the concrete *Error carries the message and details.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrInvalidID  = errors.New("invalid ID")
+	ErrValidation = errors.New("validation failed")
+	ErrConflict   = errors.New("conflict")
+	ErrBadRequest = errors.New("bad request")
+
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrForbidden          = errors.New("forbidden")
+	ErrUnavailable        = errors.New("unavailable")
+)
+
+// Error codes returned in API error bodies
+const (
+	CodeNotFound   = "NOT_FOUND"
+	CodeInvalidID  = "INVALID_ID"
+	CodeValidation = "VALIDATION_ERROR"
+	CodeConflict   = "CONFLICT"
+	CodeBadRequest = "BAD_REQUEST"
+	CodeInternal   = "INTERNAL_ERROR"
+
+	CodePreconditionFailed = "PRECONDITION_FAILED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeUnavailable        = "SERVICE_UNAVAILABLE"
+)
+
+// Error is a domain error of a given kind with a client facing message
+type Error struct {
+	Kind    error
+	Message string
+	Details interface{}
+	Err     error
+}
+
+// Error returns the client facing message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap exposes both the kind and the underlying cause to errors.Is and errors.As
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// New creates an error of the given kind
+func New(kind error, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap creates an error of the given kind that keeps the underlying cause
+func Wrap(kind error, message string, cause error) *Error {
+	return &Error{Kind: kind, Message: message, Err: cause}
+}
+
+// NotFound creates a not found error
+func NotFound(message string) *Error {
+	return New(ErrNotFound, message)
+}
+
+// InvalidID creates an invalid identifier error
+func InvalidID(message string) *Error {
+	return New(ErrInvalidID, message)
+}
+
+// Validation creates a validation error with optional details such as per-field messages
+func Validation(message string, details interface{}) *Error {
+	return &Error{Kind: ErrValidation, Message: message, Details: details}
+}
+
+// Conflict creates a conflict error
+func Conflict(message string) *Error {
+	return New(ErrConflict, message)
+}
+
+// BadRequest creates an error for malformed requests
+func BadRequest(message string) *Error {
+	return New(ErrBadRequest, message)
+}
+
+// PreconditionFailed creates an error for a failed conditional request such as a stale If-Match
+func PreconditionFailed(message string) *Error {
+	return New(ErrPreconditionFailed, message)
+}
+
+// Forbidden creates an error for requests the caller is not allowed to make
+func Forbidden(message string) *Error {
+	return New(ErrForbidden, message)
+}
+
+// Unavailable creates an error for a feature that is not configured or temporarily unavailable
+func Unavailable(message string) *Error {
+	return New(ErrUnavailable, message)
+}
+
+// Code returns the API error code matching the kind of err
+func Code(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrInvalidID):
+		return CodeInvalidID
+	case errors.Is(err, ErrValidation):
+		return CodeValidation
+	case errors.Is(err, ErrConflict):
+		return CodeConflict
+	case errors.Is(err, ErrBadRequest):
+		return CodeBadRequest
+	case errors.Is(err, ErrPreconditionFailed):
+		return CodePreconditionFailed
+	case errors.Is(err, ErrForbidden):
+		return CodeForbidden
+	case errors.Is(err, ErrUnavailable):
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
+
+// DetailsOf returns the details attached to err, if any
+func DetailsOf(err error) interface{} {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Details
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package cardvault
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// Card brands detected from the number prefix
+const (
+	BrandVisa       = "visa"
+	BrandMastercard = "mastercard"
+	BrandAmex       = "amex"
+	BrandDiscover   = "discover"
+	BrandRuPay      = "rupay"
+	BrandUnknown    = "unknown"
+)
+
+// Lengths a card number may have
+const (
+	MinNumberLength = 12
+	MaxNumberLength = 19
+)
+
+// tokenPrefix marks card tokens so they are never mistaken for card numbers
+const tokenPrefix = "card_"
+
+// CardNumber is a card number that masks itself when printed, so it never reaches a log in full
+type CardNumber string
+
+// String returns the masked number
+func (n CardNumber) String() string {
+	return Mask(string(n))
+}
+
+// GoString returns the masked number for %#v
+func (n CardNumber) GoString() string {
+	return Mask(string(n))
+}
+
+// Normalize removes the spaces and dashes card numbers are commonly written with
+func Normalize(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(number))
+}
+
+// Valid reports whether a normalized number has a card number length, only digits and a
+// correct Luhn check digit
+func Valid(number string) bool {
+	if len(number) < MinNumberLength || len(number) > MaxNumberLength {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// Brand detects the card brand from the number prefix
+func Brand(number string) string {
+	prefix := func(length int) int {
+		if len(number) < length {
+			return -1
+		}
+		value := 0
+		for _, digit := range number[:length] {
+			value = value*10 + int(digit-'0')
+		}
+		return value
+	}
+
+	switch {
+	case strings.HasPrefix(number, "4"):
+		return BrandVisa
+	case prefix(2) >= 51 && prefix(2) <= 55, prefix(4) >= 2221 && prefix(4) <= 2720:
+		return BrandMastercard
+	case prefix(2) == 34, prefix(2) == 37:
+		return BrandAmex
+	case prefix(4) == 6011, prefix(2) == 65, prefix(3) >= 644 && prefix(3) <= 649:
+		return BrandDiscover
+	case prefix(2) == 60, prefix(2) == 81, prefix(2) == 82, prefix(3) == 508:
+		return BrandRuPay
+	default:
+		return BrandUnknown
+	}
+}
+
+// Last4 returns the last four digits of a number
+func Last4(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return number[len(number)-4:]
+}
+
+// Mask hides every digit but the last four, e.g. "**** **** **** 4242"
+func Mask(number string) string {
+	number = Normalize(number)
+	if number == "" {
+		return ""
+	}
+	return "**** **** **** " + Last4(number)
+}
+
+// NewToken returns a random opaque token that identifies a stored card
+func NewToken() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return tokenPrefix + hex.EncodeToString(raw), nil
+}
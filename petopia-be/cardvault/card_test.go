@@ -0,0 +1,96 @@
+package cardvault
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   bool
+	}{
+		{"visa", "4242424242424242", true},
+		{"mastercard", "5555555555554444", true},
+		{"amex", "378282246310005", true},
+		{"nineteen digits", "4012888888881881003", true},
+		{"wrong check digit", "4242424242424241", false},
+		{"too short", "42424242424", false},
+		{"too long", "42424242424242424242", false},
+		{"letters", "4242a24242424242", false},
+		{"not normalized", "4242 4242 4242 4242", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Valid(tt.number); got != tt.want {
+				t.Errorf("Valid(%q) = %v, want %v", tt.number, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrand(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+	}{
+		{"4242424242424242", BrandVisa},
+		{"5555555555554444", BrandMastercard},
+		{"2223003122003222", BrandMastercard},
+		{"2720990000000000", BrandMastercard},
+		{"2721000000000000", BrandUnknown},
+		{"378282246310005", BrandAmex},
+		{"341111111111111", BrandAmex},
+		{"6011111111111117", BrandDiscover},
+		{"6500000000000002", BrandDiscover},
+		{"6440000000000000", BrandDiscover},
+		{"6070000000000000", BrandRuPay},
+		{"8100000000000000", BrandRuPay},
+		{"5085000000000000", BrandRuPay},
+		{"3530111333300000", BrandUnknown},
+		{"", BrandUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			if got := Brand(tt.number); got != tt.want {
+				t.Errorf("Brand(%q) = %q, want %q", tt.number, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   string
+	}{
+		{"plain", "4242424242424242", "**** **** **** 4242"},
+		{"spaces and dashes", " 4242-4242 4242-1234 ", "**** **** **** 1234"},
+		{"short", "123", "**** **** **** 123"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mask(tt.number); got != tt.want {
+				t.Errorf("Mask(%q) = %q, want %q", tt.number, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardNumberFormatting(t *testing.T) {
+	number := CardNumber("4242424242424242")
+	want := "**** **** **** 4242"
+
+	for _, format := range []string{"%s", "%v", "%#v"} {
+		if got := fmt.Sprintf(format, number); got != want {
+			t.Errorf("Sprintf(%q) = %q, want %q", format, got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package cardvault encrypts payment card numbers at rest with envelope encryption and provides
+// the validation and masking used wherever card numbers are handled.
+//
+// Every card number is sealed with its own random data key using AES-256-GCM; the data key is
+// in turn sealed with the configured key encryption key. Only the sealed values, a keyed
+// fingerprint for duplicate detection and display fields are stored.
+package cardvault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+// KeySize is the size in bytes of the key encryption key and of every data key
+const KeySize = 32
+
+// Vault errors
+var (
+	ErrInvalidKey      = errors.New("card vault key must be 32 bytes encoded as base64")
+	ErrKeyMismatch     = errors.New("card was sealed with a different vault key")
+	ErrCorruptEnvelope = errors.New("sealed card data is corrupt")
+)
+
+// Envelope is a card number sealed by a Vault
+type Envelope struct {
+	// Ciphertext is the card number sealed with the data key, prefixed by its nonce
+	Ciphertext []byte
+
+	// WrappedKey is the data key sealed with the key encryption key, prefixed by its nonce
+	WrappedKey []byte
+
+	// KeyID identifies the key encryption key that wrapped the data key
+	KeyID string
+}
+
+// Vault seals and opens card numbers with a key encryption key
+type Vault struct {
+	kek    cipher.AEAD
+	keyID  string
+	macKey []byte
+}
+
+// New creates a Vault from a base64 encoded 32 byte key encryption key
+func New(encodedKey string) (*Vault, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+
+	kek, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive separate values for the key ID and the fingerprint key so neither reveals the key
+	keyID := sha256.Sum256(append([]byte("petopia-card-vault-key-id:"), key...))
+	macKey := sha256.Sum256(append([]byte("petopia-card-vault-fingerprint:"), key...))
+
+	return &Vault{
+		kek:    kek,
+		keyID:  hex.EncodeToString(keyID[:8]),
+		macKey: macKey[:],
+	}, nil
+}
+
+// KeyID identifies the vault's key encryption key
+func (v *Vault) KeyID() string {
+	return v.keyID
+}
+
+// Seal encrypts a card number under a fresh data key
+func (v *Vault) Seal(number string) (*Envelope, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	dek, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := seal(dek, []byte(number))
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := seal(v.kek, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		Ciphertext: ciphertext,
+		WrappedKey: wrappedKey,
+		KeyID:      v.keyID,
+	}, nil
+}
+
+// Open decrypts a card number sealed by this vault's key
+func (v *Vault) Open(envelope *Envelope) (string, error) {
+	if envelope.KeyID != v.keyID {
+		return "", ErrKeyMismatch
+	}
+
+	dataKey, err := open(v.kek, envelope.WrappedKey)
+	if err != nil {
+		return "", err
+	}
+
+	dek, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	number, err := open(dek, envelope.Ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(number), nil
+}
+
+// Fingerprint returns a keyed hash of a card number that identifies the same card across
+// envelopes without storing the number
+func (v *Vault) Fingerprint(number string) string {
+	mac := hmac.New(sha256.New, v.macKey)
+	mac.Write([]byte(number))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newGCM creates an AES-256-GCM cipher for a key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce that is prepended to the result
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts a value produced by seal
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrCorruptEnvelope
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrCorruptEnvelope
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,119 @@
+package cardvault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+// testKey returns a base64 encoded vault key filled with b
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, KeySize))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{"valid", testKey(1), nil},
+		{"not base64", "not a key!", ErrInvalidKey},
+		{"too short", base64.StdEncoding.EncodeToString(make([]byte, 16)), ErrInvalidKey},
+		{"too long", base64.StdEncoding.EncodeToString(make([]byte, 48)), ErrInvalidKey},
+		{"empty", "", ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("New() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	vault, err := New(testKey(1))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	otherVault, err := New(testKey(2))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	const number = "4242424242424242"
+	envelope, err := vault.Seal(number)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if bytes.Contains(envelope.Ciphertext, []byte(number)) {
+		t.Fatal("Seal() ciphertext contains the card number")
+	}
+	if envelope.KeyID != vault.KeyID() {
+		t.Errorf("Seal() key ID = %q, want %q", envelope.KeyID, vault.KeyID())
+	}
+
+	tampered := func(change func(envelope *Envelope)) *Envelope {
+		copied := &Envelope{
+			Ciphertext: bytes.Clone(envelope.Ciphertext),
+			WrappedKey: bytes.Clone(envelope.WrappedKey),
+			KeyID:      envelope.KeyID,
+		}
+		change(copied)
+		return copied
+	}
+
+	tests := []struct {
+		name     string
+		vault    *Vault
+		envelope *Envelope
+		want     string
+		wantErr  error
+	}{
+		{"round trip", vault, envelope, number, nil},
+		{"different key", otherVault, envelope, "", ErrKeyMismatch},
+		{"key ID of other key", vault, tampered(func(e *Envelope) { e.KeyID = otherVault.KeyID() }), "", ErrKeyMismatch},
+		{"wrapped key of other key", otherVault, tampered(func(e *Envelope) { e.KeyID = otherVault.KeyID() }), "", ErrCorruptEnvelope},
+		{"changed ciphertext", vault, tampered(func(e *Envelope) { e.Ciphertext[len(e.Ciphertext)-1] ^= 1 }), "", ErrCorruptEnvelope},
+		{"truncated wrapped key", vault, tampered(func(e *Envelope) { e.WrappedKey = e.WrappedKey[:4] }), "", ErrCorruptEnvelope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.vault.Open(tt.envelope)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Open() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Open() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSealUsesFreshDataKeys(t *testing.T) {
+	vault, err := New(testKey(1))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	first, err := vault.Seal("4242424242424242")
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	second, err := vault.Seal("4242424242424242")
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	if bytes.Equal(first.Ciphertext, second.Ciphertext) || bytes.Equal(first.WrappedKey, second.WrappedKey) {
+		t.Error("Seal() produced the same envelope twice")
+	}
+	if vault.Fingerprint("4242424242424242") != vault.Fingerprint("4242424242424242") {
+		t.Error("Fingerprint() differs for the same number")
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 )
 
 type Config struct {
@@ -24,6 +25,22 @@ type Config struct {
 
 	// CORS config
 	CORSAllowedOrigin string
+
+	// Admin config
+	AdminAPIKey string
+
+	// Soft-deleted products are purged after this many days
+	ProductRetentionDays int
+
+	// Product feed config
+	StorefrontURL   string
+	CatalogCurrency string
+
+	// Products with this many sellable units or fewer are reported as low stock
+	LowStockThreshold int
+
+	// Base64 encoded 32 byte key that wraps the keys sealing stored card numbers
+	CardVaultKey string
 }
 
 func Load() *Config {
@@ -43,6 +60,17 @@ func Load() *Config {
 		RabbitMQVhost:    getEnv("RABBITMQ_VHOST", ""),
 
 		CORSAllowedOrigin: getEnv("CORS_ALLOWED_ORIGIN", "*"),
+
+		AdminAPIKey: getEnv("ADMIN_API_KEY", ""),
+
+		ProductRetentionDays: getEnvInt("PRODUCT_RETENTION_DAYS", 30),
+
+		StorefrontURL:   getEnv("STOREFRONT_URL", "http://localhost"),
+		CatalogCurrency: getEnv("CATALOG_CURRENCY", "USD"),
+
+		LowStockThreshold: getEnvInt("LOW_STOCK_THRESHOLD", 5),
+
+		CardVaultKey: getEnv("CARD_VAULT_KEY", ""),
 	}
 }
 
@@ -52,3 +80,10 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
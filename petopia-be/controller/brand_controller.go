@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/service"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// BrandController handles the HTTP requests for brands
+type BrandController struct {
+	BrandService service.BrandService
+}
+
+// NewBrandController creates a new brand controller
+func NewBrandController(bs service.BrandService) *BrandController {
+	return &BrandController{
+		BrandService: bs,
+	}
+}
+
+// CreateBrand handles brand creation
+func (c *BrandController) CreateBrand(w http.ResponseWriter, r *http.Request) {
+	var requestDTO dto.BrandRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	if requestDTO.BrandName == "" {
+		writeBadRequest(w, r, "Brand name is required")
+		return
+	}
+
+	createdBrand, err := c.BrandService.CreateBrand(r.Context(), requestDTO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdBrand)
+}
+
+// GetBrandByID retrieves a brand by its ID
+func (c *BrandController) GetBrandByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	brand, err := c.BrandService.GetBrandByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(brand)
+}
+
+// GetBrandByBrandID retrieves a brand by its brand_id
+func (c *BrandController) GetBrandByBrandID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	brandIDStr := vars["brand_id"]
+
+	brandID, err := strconv.Atoi(brandIDStr)
+	if err != nil {
+		writeBadRequest(w, r, "Invalid brand ID")
+		return
+	}
+
+	brand, err := c.BrandService.GetBrandByBrandID(r.Context(), brandID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(brand)
+}
+
+// ListBrands returns a list of brands with pagination
+func (c *BrandController) ListBrands(w http.ResponseWriter, r *http.Request) {
+	page, limit := parsePagination(r)
+	activeOnly := r.URL.Query().Get("active") == "true"
+
+	result, err := c.BrandService.GetAllBrands(r.Context(), page, limit, activeOnly)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetBrandsByCategory returns active brands that sell in a category
+func (c *BrandController) GetBrandsByCategory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	category := vars["category"]
+
+	brands, err := c.BrandService.GetBrandsByCategory(r.Context(), category)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(brands)
+}
+
+// SearchBrands searches for active brands by term
+func (c *BrandController) SearchBrands(w http.ResponseWriter, r *http.Request) {
+	term := r.URL.Query().Get("q")
+	if term == "" {
+		writeBadRequest(w, r, "Search term is required")
+		return
+	}
+
+	brands, err := c.BrandService.SearchBrands(r.Context(), term)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(brands)
+}
+
+// UpdateBrand updates an existing brand
+func (c *BrandController) UpdateBrand(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var requestDTO dto.BrandRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	if requestDTO.BrandName == "" {
+		writeBadRequest(w, r, "Brand name is required")
+		return
+	}
+
+	err := c.BrandService.UpdateBrand(r.Context(), id, requestDTO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// Get the updated brand
+	updatedBrand, err := c.BrandService.GetBrandByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(updatedBrand)
+}
+
+// DeactivateBrand marks a brand as inactive
+func (c *BrandController) DeactivateBrand(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	err := c.BrandService.DeactivateBrand(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/middleware"
+	"petopia-be/service"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// CardController handles the HTTP requests for a user's saved payment cards
+type CardController struct {
+	CardService service.CardService
+}
+
+// NewCardController creates a new card controller
+func NewCardController(cs service.CardService) *CardController {
+	return &CardController{
+		CardService: cs,
+	}
+}
+
+// AddCard saves a payment card of a user and returns it masked
+func (c *CardController) AddCard(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	var requestDTO dto.CardRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	card, err := c.CardService.AddCard(r.Context(), userID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(card)
+}
+
+// ListCards returns the saved payment cards of a user with masked numbers
+func (c *CardController) ListCards(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	cards, err := c.CardService.ListCards(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(cards)
+}
+
+// DeleteCard removes a saved payment card of a user by its token
+func (c *CardController) DeleteCard(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	token := mux.Vars(r)["token"]
+	if err := c.CardService.DeleteCard(r.Context(), userID, token, middleware.GetUserID(r.Context())); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/middleware"
+	"petopia-be/service"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// CartController handles the HTTP requests for user shopping carts
+type CartController struct {
+	CartService service.CartService
+}
+
+// NewCartController creates a new cart controller
+func NewCartController(cs service.CartService) *CartController {
+	return &CartController{
+		CartService: cs,
+	}
+}
+
+// GetCart returns a user's cart with line and cart totals
+func (c *CartController) GetCart(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	cart, err := c.CartService.GetCart(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(cart)
+}
+
+// AddItem adds a product to a user's cart and returns the updated cart
+func (c *CartController) AddItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	var requestDTO dto.CartItemRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	cart, err := c.CartService.AddItem(r.Context(), userID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(cart)
+}
+
+// UpdateItemQuantity changes the quantity of a product in a user's cart and returns the updated cart
+func (c *CartController) UpdateItemQuantity(w http.ResponseWriter, r *http.Request) {
+	userID, productID, ok := parseCartItemPath(w, r)
+	if !ok {
+		return
+	}
+
+	var requestDTO dto.CartQuantityRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	cart, err := c.CartService.UpdateItemQuantity(r.Context(), userID, productID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(cart)
+}
+
+// RemoveItem removes a product from a user's cart
+func (c *CartController) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	userID, productID, ok := parseCartItemPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.CartService.RemoveItem(r.Context(), userID, productID, middleware.GetUserID(r.Context())); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ClearCart removes every product from a user's cart
+func (c *CartController) ClearCart(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	if err := c.CartService.ClearCart(r.Context(), userID, middleware.GetUserID(r.Context())); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseCartItemPath reads the user and product IDs from the path, writing a 400 when either is invalid
+func parseCartItemPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return 0, 0, false
+	}
+
+	productID, err := strconv.Atoi(vars["product_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid product ID")
+		return 0, 0, false
+	}
+
+	return userID, productID, true
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/middleware"
+	"petopia-be/service"
+)
+
+// Headers used by checkout
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// CheckoutController handles the HTTP requests that turn carts into orders
+type CheckoutController struct {
+	CheckoutService service.CheckoutService
+}
+
+// NewCheckoutController creates a new checkout controller
+func NewCheckoutController(cs service.CheckoutService) *CheckoutController {
+	return &CheckoutController{
+		CheckoutService: cs,
+	}
+}
+
+// Checkout places an order for a user's cart. It responds 201 with the new order, or 200 with
+// the original order when the Idempotency-Key was already used for a checkout.
+func (c *CheckoutController) Checkout(w http.ResponseWriter, r *http.Request) {
+	var requestDTO dto.CheckoutRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+	order, created, err := c.CheckoutService.Checkout(r.Context(), requestDTO, idempotencyKey, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.Header().Set(IdempotentReplayedHeader, "true")
+	}
+	json.NewEncoder(w).Encode(order)
+}
@@ -2,10 +2,16 @@ package controller
 
 import (
 	"encoding/json"
+	"io"
+	"log"
 	"net/http"
+	"petopia-be/apperrors"
 	"petopia-be/dto"
+	"petopia-be/middleware"
 	"petopia-be/service"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -14,10 +20,22 @@ import (
 const (
 	ContentTypeHeaderKey = "Content-Type"
 	ContentTypeJSONValue = "application/json"
-	ErrProductNotFound   = "product not found"
-	ErrInvalidProductID  = "invalid product ID"
+	ETagHeaderKey        = "ETag"
+	IfMatchHeaderKey     = "If-Match"
 )
 
+// SearchModeText selects the relevance-ranked text index search
+const SearchModeText = "text"
+
+// Page sizes used when listing; larger limits are clamped to MaxPageSize
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// MaxImportBodyBytes caps the size of an uploaded product catalog
+const MaxImportBodyBytes = 10 << 20
+
 // ProductController handles the HTTP requests for products
 type ProductController struct {
 	ProductService service.ProductService
@@ -34,42 +52,48 @@ func NewProductController(ps service.ProductService) *ProductController {
 func (c *ProductController) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	var requestDTO dto.ProductRequestDTO
 	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		writeBadRequest(w, r, "Invalid request payload")
 		return
 	}
 
 	// Call service layer
 	createdProduct, err := c.ProductService.CreateProduct(r.Context(), requestDTO)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.Header().Set(ETagHeaderKey, dto.ProductETag(createdProduct.UpdatedAt))
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(createdProduct)
 }
 
-// GetProductByID retrieves a product by its ID
+// GetProductByID retrieves a product by its ID. Admins may pass include_deleted=true to
+// retrieve a soft-deleted product.
 func (c *ProductController) GetProductByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	product, err := c.ProductService.GetProductByID(r.Context(), id)
+	includeDeleted, err := parseIncludeDeleted(r)
 	if err != nil {
-		if err.Error() == ErrProductNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
-			return
-		}
-		if err.Error() == ErrInvalidProductID {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
+		return
+	}
+
+	var product *dto.ProductResponseDTO
+	if includeDeleted {
+		product, err = c.ProductService.GetProductByIDIncludingDeleted(r.Context(), id)
+	} else {
+		product, err = c.ProductService.GetProductByID(r.Context(), id)
+	}
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.Header().Set(ETagHeaderKey, dto.ProductETag(product.UpdatedAt))
 	json.NewEncoder(w).Encode(product)
 }
 
@@ -80,17 +104,13 @@ func (c *ProductController) GetProductByProductID(w http.ResponseWriter, r *http
 
 	productID, err := strconv.Atoi(productIDStr)
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		writeBadRequest(w, r, "Invalid product ID")
 		return
 	}
 
 	product, err := c.ProductService.GetProductByProductID(r.Context(), productID)
 	if err != nil {
-		if err.Error() == ErrProductNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -98,43 +118,35 @@ func (c *ProductController) GetProductByProductID(w http.ResponseWriter, r *http
 	json.NewEncoder(w).Encode(product)
 }
 
-// ListProducts returns a list of products with pagination
+// ListProducts returns a list of products matching the query filters with sorting and pagination
 func (c *ProductController) ListProducts(w http.ResponseWriter, r *http.Request) {
-	page := int64(1)
-	limit := int64(10)
-
-	queryParams := r.URL.Query()
-
-	if pageStr := queryParams.Get("page"); pageStr != "" {
-		if pageNum, err := strconv.ParseInt(pageStr, 10, 64); err == nil && pageNum > 0 {
-			page = pageNum
-		}
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
-	if limitStr := queryParams.Get("limit"); limitStr != "" {
-		if limitNum, err := strconv.ParseInt(limitStr, 10, 64); err == nil && limitNum > 0 {
-			limit = limitNum
-		}
+	result, err := c.ProductService.ListProducts(r.Context(), filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
-	category := queryParams.Get("category")
-	if category != "" {
-		// If a category is specified, get products by category
-		products, err := c.ProductService.GetProductsByCategory(r.Context(), category)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(result)
+}
 
-		w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
-		json.NewEncoder(w).Encode(products)
+// GetAvailableProducts returns only available products
+func (c *ProductController) GetAvailableProducts(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	// Get all products with pagination
-	result, err := c.ProductService.GetAllProducts(r.Context(), page, limit)
+	result, err := c.ProductService.GetAvailableProducts(r.Context(), filter)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -142,28 +154,53 @@ func (c *ProductController) ListProducts(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(result)
 }
 
-// GetAvailableProducts returns only available products
-func (c *ProductController) GetAvailableProducts(w http.ResponseWriter, r *http.Request) {
-	page := int64(1)
-	limit := int64(10)
+// SearchProducts searches for products by term.
+// With facets=true the hits are paginated and returned with facet counts for the filter sidebar;
+// with mode=text the weighted text index is used and results are paginated by relevance.
+func (c *ProductController) SearchProducts(w http.ResponseWriter, r *http.Request) {
+	term := r.URL.Query().Get("q")
+	if term == "" {
+		writeBadRequest(w, r, "Search term is required")
+		return
+	}
 
-	queryParams := r.URL.Query()
+	if r.URL.Query().Get("facets") == "true" {
+		c.searchProductsFaceted(w, r)
+		return
+	}
 
-	if pageStr := queryParams.Get("page"); pageStr != "" {
-		if pageNum, err := strconv.ParseInt(pageStr, 10, 64); err == nil && pageNum > 0 {
-			page = pageNum
-		}
+	if r.URL.Query().Get("mode") == SearchModeText {
+		c.searchProductsRanked(w, r)
+		return
 	}
 
-	if limitStr := queryParams.Get("limit"); limitStr != "" {
-		if limitNum, err := strconv.ParseInt(limitStr, 10, 64); err == nil && limitNum > 0 {
-			limit = limitNum
-		}
+	products, err := c.ProductService.SearchProducts(r.Context(), term)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
-	result, err := c.ProductService.GetAvailableProducts(r.Context(), page, limit)
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(products)
+}
+
+// searchProductsRanked handles the relevance-ranked text search mode
+func (c *ProductController) searchProductsRanked(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseProductFilter(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
+		return
+	}
+
+	// Match the regex search, which only returns available products unless asked otherwise
+	if filter.Availability == nil {
+		available := true
+		filter.Availability = &available
+	}
+
+	result, err := c.ProductService.SearchProductsRanked(r.Context(), filter)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
@@ -171,22 +208,36 @@ func (c *ProductController) GetAvailableProducts(w http.ResponseWriter, r *http.
 	json.NewEncoder(w).Encode(result)
 }
 
-// SearchProducts searches for products by term
-func (c *ProductController) SearchProducts(w http.ResponseWriter, r *http.Request) {
-	term := r.URL.Query().Get("q")
-	if term == "" {
-		http.Error(w, "Search term is required", http.StatusBadRequest)
+// searchProductsFaceted handles the faceted search mode. Unlike the other modes, availability is
+// only filtered when requested, so the availability facet counts every value.
+func (c *ProductController) searchProductsFaceted(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	products, err := c.ProductService.SearchProducts(r.Context(), term)
+	// price_buckets is a comma separated list of ascending bucket boundaries, e.g. 0,10,25,50
+	var priceBoundaries []float64
+	if bucketsStr := r.URL.Query().Get("price_buckets"); bucketsStr != "" {
+		for _, boundaryStr := range strings.Split(bucketsStr, ",") {
+			boundary, err := strconv.ParseFloat(strings.TrimSpace(boundaryStr), 64)
+			if err != nil {
+				writeBadRequest(w, r, "invalid price buckets")
+				return
+			}
+			priceBoundaries = append(priceBoundaries, boundary)
+		}
+	}
+
+	result, err := c.ProductService.SearchProductsFaceted(r.Context(), filter, priceBoundaries)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
-	json.NewEncoder(w).Encode(products)
+	json.NewEncoder(w).Encode(result)
 }
 
 // UpdateProduct updates an existing product
@@ -196,53 +247,318 @@ func (c *ProductController) UpdateProduct(w http.ResponseWriter, r *http.Request
 
 	var requestDTO dto.ProductRequestDTO
 	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		writeBadRequest(w, r, "Invalid request payload")
 		return
 	}
 
-	err := c.ProductService.UpdateProduct(r.Context(), id, requestDTO)
+	expectedUpdatedAt, err := parseIfMatch(r)
 	if err != nil {
-		if err.Error() == ErrProductNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
-			return
-		}
-		if err.Error() == ErrInvalidProductID {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
+		return
+	}
+
+	err = c.ProductService.UpdateProduct(r.Context(), id, requestDTO, expectedUpdatedAt)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	// Get the updated product
 	updatedProduct, err := c.ProductService.GetProductByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.Header().Set(ETagHeaderKey, dto.ProductETag(updatedProduct.UpdatedAt))
 	json.NewEncoder(w).Encode(updatedProduct)
 }
 
-// DeleteProduct deletes a product
+// PatchProduct applies a JSON Merge Patch to an existing product, honoring If-Match
+func (c *ProductController) PatchProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	expectedUpdatedAt, err := parseIfMatch(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	patchedProduct, err := c.ProductService.PatchProduct(r.Context(), id, patch, expectedUpdatedAt)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.Header().Set(ETagHeaderKey, dto.ProductETag(patchedProduct.UpdatedAt))
+	json.NewEncoder(w).Encode(patchedProduct)
+}
+
+// DeleteProduct soft deletes a product on behalf of the calling user
 func (c *ProductController) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	err := c.ProductService.DeleteProduct(r.Context(), id)
+	err := c.ProductService.DeleteProduct(r.Context(), id, middleware.GetUserID(r.Context()))
 	if err != nil {
-		if err.Error() == ErrProductNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
-			return
-		}
-		if err.Error() == ErrInvalidProductID {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreProduct restores a soft-deleted product. Admin only.
+func (c *ProductController) RestoreProduct(w http.ResponseWriter, r *http.Request) {
+	if !middleware.IsAdmin(r.Context()) {
+		writeError(w, r, ErrAdminRequired)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	product, err := c.ProductService.RestoreProduct(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.Header().Set(ETagHeaderKey, dto.ProductETag(product.UpdatedAt))
+	json.NewEncoder(w).Encode(product)
+}
+
+// BulkProducts applies a batch of operations to products selected by ID list or filter
+// and returns a result per product. Admin only.
+func (c *ProductController) BulkProducts(w http.ResponseWriter, r *http.Request) {
+	if !middleware.IsAdmin(r.Context()) {
+		writeError(w, r, ErrAdminRequired)
+		return
+	}
+
+	var request dto.BulkProductRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	response, err := c.ProductService.BulkUpdateProducts(r.Context(), request, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ImportProducts upserts products from a CSV or NDJSON catalog and returns a per-row report.
+// The format comes from the format query parameter or the Content-Type; dry_run=true only validates.
+// Admin only.
+func (c *ProductController) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	if !middleware.IsAdmin(r.Context()) {
+		writeError(w, r, ErrAdminRequired)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = importFormatFromContentType(r.Header.Get(ContentTypeHeaderKey))
+	}
+
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		parsed, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			writeBadRequest(w, r, "invalid dry_run")
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		dryRun = parsed
+	}
+
+	body := http.MaxBytesReader(w, r.Body, MaxImportBodyBytes)
+	report, err := c.ProductService.ImportProducts(r.Context(), format, body, dryRun)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(report)
+}
+
+// ExportProducts streams every product matching the list filters as CSV, NDJSON or a
+// Google Merchant XML feed, chosen with the format query parameter (csv by default).
+// Pagination parameters are ignored.
+func (c *ProductController) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = dto.FormatCSV
+	}
+
+	export, err := c.ProductService.ExportProducts(r.Context(), filter, format)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, export.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="products.`+format+`"`)
+
+	// The status is already sent once rows are streaming, so failures can only be logged
+	if err := export.Stream(r.Context(), w); err != nil {
+		log.Printf("request %s: product export failed: %v", middleware.GetRequestID(r.Context()), err)
+	}
+}
+
+// importFormatFromContentType maps an upload Content-Type to an import format
+func importFormatFromContentType(contentType string) string {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch mediaType {
+	case "text/csv":
+		return dto.FormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return dto.FormatNDJSON
+	default:
+		return ""
+	}
+}
+
+// parsePagination reads the page and limit query parameters, falling back to page 1 and
+// DefaultPageSize items and clamping the limit to MaxPageSize
+func parsePagination(r *http.Request) (int64, int64) {
+	page := int64(1)
+	limit := int64(DefaultPageSize)
+
+	queryParams := r.URL.Query()
+
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		if pageNum, err := strconv.ParseInt(pageStr, 10, 64); err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	if limitStr := queryParams.Get("limit"); limitStr != "" {
+		if limitNum, err := strconv.ParseInt(limitStr, 10, 64); err == nil && limitNum > 0 {
+			limit = limitNum
+		}
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	return page, limit
+}
+
+// parseProductFilter builds a ProductFilter from the list query parameters
+func parseProductFilter(r *http.Request) (dto.ProductFilter, error) {
+	page, limit := parsePagination(r)
+	queryParams := r.URL.Query()
+
+	filter := dto.ProductFilter{
+		Category:   queryParams.Get("category"),
+		SearchTerm: queryParams.Get("q"),
+		Page:       page,
+		Limit:      limit,
+		UseCursor:  queryParams.Get("pagination") == "cursor",
+		Cursor:     queryParams.Get("cursor"),
+		SortBy:     queryParams.Get("sort_by"),
+		SortOrder:  queryParams.Get("sort_order"),
+	}
+
+	if brandIDStr := queryParams.Get("brand_id"); brandIDStr != "" {
+		brandID, err := strconv.Atoi(brandIDStr)
+		if err != nil {
+			return filter, apperrors.BadRequest("invalid brand_id")
+		}
+		filter.BrandID = brandID
+	}
+
+	if sellerIDStr := queryParams.Get("seller_id"); sellerIDStr != "" {
+		sellerID, err := strconv.Atoi(sellerIDStr)
+		if err != nil {
+			return filter, apperrors.BadRequest("invalid seller_id")
+		}
+		filter.SellerID = sellerID
+	}
+
+	for param, target := range map[string]**float64{
+		"min_price":    &filter.MinPrice,
+		"max_price":    &filter.MaxPrice,
+		"min_discount": &filter.MinDiscount,
+	} {
+		valueStr := queryParams.Get(param)
+		if valueStr == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return filter, apperrors.BadRequest("invalid " + param)
+		}
+		*target = &value
+	}
+
+	if availabilityStr := queryParams.Get("availability"); availabilityStr != "" {
+		availability, err := strconv.ParseBool(availabilityStr)
+		if err != nil {
+			return filter, apperrors.BadRequest("invalid availability")
+		}
+		filter.Availability = &availability
+	}
+
+	includeDeleted, err := parseIncludeDeleted(r)
+	if err != nil {
+		return filter, err
+	}
+	filter.IncludeDeleted = includeDeleted
+
+	return filter, nil
+}
+
+// parseIncludeDeleted reads the include_deleted query parameter, which only admins may set
+func parseIncludeDeleted(r *http.Request) (bool, error) {
+	includeDeletedStr := r.URL.Query().Get("include_deleted")
+	if includeDeletedStr == "" {
+		return false, nil
+	}
+
+	includeDeleted, err := strconv.ParseBool(includeDeletedStr)
+	if err != nil {
+		return false, apperrors.BadRequest("invalid include_deleted")
+	}
+	if includeDeleted && !middleware.IsAdmin(r.Context()) {
+		return false, ErrAdminRequired
+	}
+	return includeDeleted, nil
+}
+
+// parseIfMatch returns the product version required by the If-Match header, or nil when
+// the header is absent or "*"
+func parseIfMatch(r *http.Request) (*time.Time, error) {
+	ifMatch := r.Header.Get(IfMatchHeaderKey)
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	expectedUpdatedAt, err := dto.ParseProductETag(ifMatch)
+	if err != nil {
+		return nil, apperrors.PreconditionFailed("If-Match does not match any product version")
+	}
+	return &expectedUpdatedAt, nil
 }
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"petopia-be/apperrors"
+	"petopia-be/middleware"
+)
+
+// ErrAdminRequired is returned when a non-admin calls an admin only endpoint or option
+var ErrAdminRequired = apperrors.Forbidden("admin access required")
+
+// ErrorResponse is the JSON body returned for every failed request
+type ErrorResponse struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// writeError maps domain errors to HTTP status codes and writes a JSON error body
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	message := err.Error()
+
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, apperrors.ErrInvalidID), errors.Is(err, apperrors.ErrBadRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrValidation):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, apperrors.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, apperrors.ErrPreconditionFailed):
+		status = http.StatusPreconditionFailed
+	case errors.Is(err, apperrors.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, apperrors.ErrUnavailable):
+		status = http.StatusServiceUnavailable
+	default:
+		// Do not leak driver or infrastructure errors to clients
+		log.Printf("request %s failed: %v", middleware.GetRequestID(r.Context()), err)
+		message = "internal server error"
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Code:      apperrors.Code(err),
+		Message:   message,
+		Details:   apperrors.DetailsOf(err),
+		RequestID: middleware.GetRequestID(r.Context()),
+	})
+}
+
+// writeBadRequest writes a 400 error for malformed request input
+func writeBadRequest(w http.ResponseWriter, r *http.Request, message string) {
+	writeError(w, r, apperrors.BadRequest(message))
+}
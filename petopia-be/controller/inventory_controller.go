@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/middleware"
+	"petopia-be/service"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// InventoryController handles the HTTP requests for product stock
+type InventoryController struct {
+	InventoryService service.InventoryService
+}
+
+// NewInventoryController creates a new inventory controller
+func NewInventoryController(is service.InventoryService) *InventoryController {
+	return &InventoryController{
+		InventoryService: is,
+	}
+}
+
+// GetInventory returns a product's stock per seller and location
+func (c *InventoryController) GetInventory(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(mux.Vars(r)["product_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid product ID")
+		return
+	}
+
+	inventory, err := c.InventoryService.GetInventory(r.Context(), productID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(inventory)
+}
+
+// AdjustStock adds or removes stock a seller holds of a product at a location
+func (c *InventoryController) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(mux.Vars(r)["product_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid product ID")
+		return
+	}
+
+	var requestDTO dto.InventoryAdjustRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	inventory, err := c.InventoryService.AdjustStock(r.Context(), productID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(inventory)
+}
+
+// ReserveStock holds available stock of a product
+func (c *InventoryController) ReserveStock(w http.ResponseWriter, r *http.Request) {
+	productID, requestDTO, ok := parseStockQuantityRequest(w, r)
+	if !ok {
+		return
+	}
+
+	inventory, err := c.InventoryService.ReserveStock(r.Context(), productID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(inventory)
+}
+
+// ReleaseStock returns reserved stock of a product to available stock
+func (c *InventoryController) ReleaseStock(w http.ResponseWriter, r *http.Request) {
+	productID, requestDTO, ok := parseStockQuantityRequest(w, r)
+	if !ok {
+		return
+	}
+
+	inventory, err := c.InventoryService.ReleaseStock(r.Context(), productID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(inventory)
+}
+
+// ListLowStock lists products whose available stock is at or below the threshold query parameter,
+// or the configured threshold when it is absent
+func (c *InventoryController) ListLowStock(w http.ResponseWriter, r *http.Request) {
+	var threshold *int
+	if thresholdStr := r.URL.Query().Get("threshold"); thresholdStr != "" {
+		parsed, err := strconv.Atoi(thresholdStr)
+		if err != nil {
+			writeBadRequest(w, r, "Invalid threshold")
+			return
+		}
+		threshold = &parsed
+	}
+
+	lowStock, err := c.InventoryService.ListLowStock(r.Context(), threshold)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(lowStock)
+}
+
+// parseStockQuantityRequest reads the product ID from the path and the quantity from the body,
+// writing a 400 when either is invalid
+func parseStockQuantityRequest(w http.ResponseWriter, r *http.Request) (int, dto.InventoryQuantityRequestDTO, bool) {
+	var requestDTO dto.InventoryQuantityRequestDTO
+
+	productID, err := strconv.Atoi(mux.Vars(r)["product_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid product ID")
+		return 0, requestDTO, false
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return 0, requestDTO, false
+	}
+
+	return productID, requestDTO, true
+}
@@ -15,12 +15,12 @@ type TestMessageRequest struct {
 func SendTestMessage(w http.ResponseWriter, r *http.Request) {
 	var req TestMessageRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeBadRequest(w, r, "Invalid request body")
 		return
 	}
 
 	if err := rabbitmq.PublishTestMessage(req.Message); err != nil {
-		http.Error(w, "Failed to publish message", http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
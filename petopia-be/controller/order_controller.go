@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/middleware"
+	"petopia-be/service"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// OrderController handles the HTTP requests for a user's orders
+type OrderController struct {
+	OrderService service.OrderService
+}
+
+// NewOrderController creates a new order controller
+func NewOrderController(os service.OrderService) *OrderController {
+	return &OrderController{
+		OrderService: os,
+	}
+}
+
+// ListOrders returns a page of a user's orders with their products, newest first
+func (c *OrderController) ListOrders(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	page, limit := parsePagination(r)
+	orders, err := c.OrderService.ListOrders(r.Context(), userID, page, limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(orders)
+}
+
+// GetOrder returns an order of a user with its products
+func (c *OrderController) GetOrder(w http.ResponseWriter, r *http.Request) {
+	userID, orderID, ok := parseOrderPath(w, r)
+	if !ok {
+		return
+	}
+
+	order, err := c.OrderService.GetOrder(r.Context(), userID, orderID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(order)
+}
+
+// UpdateItemStatus moves an ordered product to a new delivery or return status and returns the order
+func (c *OrderController) UpdateItemStatus(w http.ResponseWriter, r *http.Request) {
+	userID, orderID, ok := parseOrderPath(w, r)
+	if !ok {
+		return
+	}
+	itemID, err := strconv.Atoi(mux.Vars(r)["item_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid item ID")
+		return
+	}
+
+	var requestDTO dto.OrderItemStatusRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	order, err := c.OrderService.UpdateItemStatus(r.Context(), userID, orderID, itemID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(order)
+}
+
+// parseOrderPath reads the user and order IDs from the path, writing a 400 when either is invalid
+func parseOrderPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return 0, 0, false
+	}
+
+	orderID, err := strconv.Atoi(vars["order_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid order ID")
+		return 0, 0, false
+	}
+
+	return userID, orderID, true
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/service"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// ReviewController handles the HTTP requests for product reviews
+type ReviewController struct {
+	ReviewService service.ReviewService
+}
+
+// NewReviewController creates a new review controller
+func NewReviewController(rs service.ReviewService) *ReviewController {
+	return &ReviewController{
+		ReviewService: rs,
+	}
+}
+
+// ListReviews returns the reviews of a product with filters and pagination
+func (c *ReviewController) ListReviews(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(mux.Vars(r)["product_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid product ID")
+		return
+	}
+
+	page, limit := parsePagination(r)
+	queryParams := r.URL.Query()
+
+	filter := dto.ReviewFilter{
+		PetType:  queryParams.Get("pet_type"),
+		PetBreed: queryParams.Get("pet_breed"),
+		PetSize:  queryParams.Get("pet_size"),
+		SortBy:   queryParams.Get("sort"),
+		Page:     page,
+		Limit:    limit,
+	}
+
+	if ratingStr := queryParams.Get("min_rating"); ratingStr != "" {
+		minRating, err := strconv.ParseFloat(ratingStr, 64)
+		if err != nil {
+			writeBadRequest(w, r, "Invalid min_rating")
+			return
+		}
+		filter.MinRating = &minRating
+	}
+
+	if verifiedStr := queryParams.Get("verified_purchase"); verifiedStr != "" {
+		verified, err := strconv.ParseBool(verifiedStr)
+		if err != nil {
+			writeBadRequest(w, r, "Invalid verified_purchase")
+			return
+		}
+		filter.VerifiedPurchase = &verified
+	}
+
+	result, err := c.ReviewService.GetProductReviews(r.Context(), productID, filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(result)
+}
+
+// CreateReview handles review creation for a product
+func (c *ReviewController) CreateReview(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(mux.Vars(r)["product_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid product ID")
+		return
+	}
+
+	var requestDTO dto.ReviewRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	createdReview, err := c.ReviewService.CreateReview(r.Context(), productID, requestDTO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdReview)
+}
+
+// UpdateReview updates an existing review of a product
+func (c *ReviewController) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, err := strconv.Atoi(vars["product_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid product ID")
+		return
+	}
+
+	var requestDTO dto.ReviewRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	updatedReview, err := c.ReviewService.UpdateReview(r.Context(), productID, vars["review_id"], requestDTO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(updatedReview)
+}
+
+// DeleteReview deletes a review of a product
+func (c *ReviewController) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, err := strconv.Atoi(vars["product_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid product ID")
+		return
+	}
+
+	if err := c.ReviewService.DeleteReview(r.Context(), productID, vars["review_id"]); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkReviewHelpful records a helpful vote for a review
+func (c *ReviewController) MarkReviewHelpful(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, err := strconv.Atoi(vars["product_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid product ID")
+		return
+	}
+
+	review, err := c.ReviewService.MarkReviewHelpful(r.Context(), productID, vars["review_id"])
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(review)
+}
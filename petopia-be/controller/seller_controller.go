@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/middleware"
+	"petopia-be/service"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// SellerController handles the HTTP requests for marketplace sellers
+type SellerController struct {
+	SellerService service.SellerService
+}
+
+// NewSellerController creates a new seller controller
+func NewSellerController(ss service.SellerService) *SellerController {
+	return &SellerController{
+		SellerService: ss,
+	}
+}
+
+// CreateSeller handles seller onboarding
+func (c *SellerController) CreateSeller(w http.ResponseWriter, r *http.Request) {
+	var requestDTO dto.SellerRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	seller, err := c.SellerService.CreateSeller(r.Context(), requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(seller)
+}
+
+// ListSellers returns a page of sellers
+func (c *SellerController) ListSellers(w http.ResponseWriter, r *http.Request) {
+	page, limit := parsePagination(r)
+	sellers, err := c.SellerService.ListSellers(r.Context(), page, limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(sellers)
+}
+
+// GetSeller retrieves a seller by ID
+func (c *SellerController) GetSeller(w http.ResponseWriter, r *http.Request) {
+	sellerID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid seller ID")
+		return
+	}
+
+	seller, err := c.SellerService.GetSeller(r.Context(), sellerID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(seller)
+}
+
+// UpdateSeller updates a seller
+func (c *SellerController) UpdateSeller(w http.ResponseWriter, r *http.Request) {
+	sellerID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid seller ID")
+		return
+	}
+
+	var requestDTO dto.SellerRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	seller, err := c.SellerService.UpdateSeller(r.Context(), sellerID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(seller)
+}
+
+// DeleteSeller soft deletes a seller without products
+func (c *SellerController) DeleteSeller(w http.ResponseWriter, r *http.Request) {
+	sellerID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid seller ID")
+		return
+	}
+
+	if err := c.SellerService.DeleteSeller(r.Context(), sellerID, middleware.GetUserID(r.Context())); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSellerProducts returns a seller's products with the same filters, sorting and pagination as the product list
+func (c *SellerController) ListSellerProducts(w http.ResponseWriter, r *http.Request) {
+	sellerID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid seller ID")
+		return
+	}
+
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	result, err := c.SellerService.ListSellerProducts(r.Context(), sellerID, filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/middleware"
+	"petopia-be/service"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// ShippingController handles the HTTP requests for shipments and their tracking
+type ShippingController struct {
+	ShippingService service.ShippingService
+}
+
+// NewShippingController creates a new shipping controller
+func NewShippingController(ss service.ShippingService) *ShippingController {
+	return &ShippingController{
+		ShippingService: ss,
+	}
+}
+
+// CreateShipments ships products of a user's order
+func (c *ShippingController) CreateShipments(w http.ResponseWriter, r *http.Request) {
+	userID, orderID, ok := parseOrderPath(w, r)
+	if !ok {
+		return
+	}
+
+	var requestDTO dto.ShipmentRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	shipments, err := c.ShippingService.CreateShipments(r.Context(), userID, orderID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(shipments)
+}
+
+// ListShipments lists the shipments of a user's order
+func (c *ShippingController) ListShipments(w http.ResponseWriter, r *http.Request) {
+	userID, orderID, ok := parseOrderPath(w, r)
+	if !ok {
+		return
+	}
+
+	shipments, err := c.ShippingService.ListShipments(r.Context(), userID, orderID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(shipments)
+}
+
+// GetShipment retrieves a shipment by ID
+func (c *ShippingController) GetShipment(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid shipment ID")
+		return
+	}
+
+	shipment, err := c.ShippingService.GetShipment(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(shipment)
+}
+
+// AssignPartner assigns the delivery partner of a shipment
+func (c *ShippingController) AssignPartner(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid shipment ID")
+		return
+	}
+
+	var requestDTO dto.DeliveryPartnerRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	shipment, err := c.ShippingService.AssignPartner(r.Context(), id, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(shipment)
+}
+
+// AddTrackingEvent appends a tracking event to a shipment and returns its timeline
+func (c *ShippingController) AddTrackingEvent(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid shipment ID")
+		return
+	}
+
+	var requestDTO dto.TrackingEventRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	tracking, err := c.ShippingService.AddTrackingEvent(r.Context(), id, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(tracking)
+}
+
+// GetTracking returns the tracking timeline of a shipment
+func (c *ShippingController) GetTracking(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid shipment ID")
+		return
+	}
+
+	tracking, err := c.ShippingService.GetTracking(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(tracking)
+}
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"petopia-be/dto"
+	"petopia-be/middleware"
+	"petopia-be/service"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// UserController handles the HTTP requests for user accounts and addresses
+type UserController struct {
+	UserService service.UserService
+}
+
+// NewUserController creates a new user controller
+func NewUserController(us service.UserService) *UserController {
+	return &UserController{
+		UserService: us,
+	}
+}
+
+// CreateUser handles user account creation
+func (c *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var requestDTO dto.UserRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	user, err := c.UserService.CreateUser(r.Context(), requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
+}
+
+// GetUser retrieves a user account with its addresses
+func (c *UserController) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	user, err := c.UserService.GetUser(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(user)
+}
+
+// UpdateUser updates a user account
+func (c *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	var requestDTO dto.UserRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	user, err := c.UserService.UpdateUser(r.Context(), userID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(user)
+}
+
+// DeleteUser soft deletes a user account and its addresses
+func (c *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	if err := c.UserService.DeleteUser(r.Context(), userID, middleware.GetUserID(r.Context())); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAddresses returns the addresses of a user
+func (c *UserController) ListAddresses(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	addresses, err := c.UserService.ListAddresses(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(addresses)
+}
+
+// AddAddress adds an address to a user
+func (c *UserController) AddAddress(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return
+	}
+
+	var requestDTO dto.AddressRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	address, err := c.UserService.AddAddress(r.Context(), userID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(address)
+}
+
+// UpdateAddress updates an address of a user
+func (c *UserController) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	userID, addressID, ok := parseAddressPath(w, r)
+	if !ok {
+		return
+	}
+
+	var requestDTO dto.AddressRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		writeBadRequest(w, r, "Invalid request payload")
+		return
+	}
+
+	address, err := c.UserService.UpdateAddress(r.Context(), userID, addressID, requestDTO, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJSONValue)
+	json.NewEncoder(w).Encode(address)
+}
+
+// DeleteAddress soft deletes an address of a user
+func (c *UserController) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	userID, addressID, ok := parseAddressPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.UserService.DeleteAddress(r.Context(), userID, addressID, middleware.GetUserID(r.Context())); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseAddressPath reads the user and address IDs from the path, writing a 400 when either is invalid
+func parseAddressPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid user ID")
+		return 0, 0, false
+	}
+
+	addressID, err := strconv.Atoi(vars["address_id"])
+	if err != nil {
+		writeBadRequest(w, r, "Invalid address ID")
+		return 0, 0, false
+	}
+
+	return userID, addressID, true
+}
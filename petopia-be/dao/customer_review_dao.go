@@ -43,7 +43,7 @@ func (dao *CustomerReviewDAO) GetCustomerReviewByID(ctx context.Context, id prim
 	var review mongo_models.CustomerReview
 	err := dao.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&review)
 	if err != nil {
-		return nil, err
+		return nil, wrapNotFound(err, "review not found")
 	}
 	return &review, nil
 }
@@ -146,6 +146,39 @@ func (dao *CustomerReviewDAO) GetVerifiedReviews(ctx context.Context, productID
 	return reviews, cursor.Err()
 }
 
+// GetReviewsByFilter retrieves a page of reviews matching the filter along with the total match count
+func (dao *CustomerReviewDAO) GetReviewsByFilter(ctx context.Context, filter bson.M, sort bson.D, page, limit int64) ([]*mongo_models.CustomerReview, int64, error) {
+	total, err := dao.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(sort) == 0 {
+		sort = bson.D{{Key: "created_at", Value: -1}}
+	}
+	opts := options.Find().
+		SetSort(sort).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+
+	cursor, err := dao.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []*mongo_models.CustomerReview
+	for cursor.Next(ctx) {
+		var review mongo_models.CustomerReview
+		if err := cursor.Decode(&review); err != nil {
+			return nil, 0, err
+		}
+		reviews = append(reviews, &review)
+	}
+
+	return reviews, total, cursor.Err()
+}
+
 // UpdateCustomerReview updates a customer review record
 func (dao *CustomerReviewDAO) UpdateCustomerReview(ctx context.Context, id primitive.ObjectID, updates bson.M) error {
 	updates["updated_at"] = time.Now()
@@ -175,6 +208,15 @@ func (dao *CustomerReviewDAO) DeleteCustomerReview(ctx context.Context, id primi
 	return err
 }
 
+// DeleteReviewsByProductIDs deletes all reviews of the given products and returns how many were removed
+func (dao *CustomerReviewDAO) DeleteReviewsByProductIDs(ctx context.Context, productIDs []int) (int64, error) {
+	result, err := dao.collection.DeleteMany(ctx, bson.M{"product_id": bson.M{"$in": productIDs}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
 // GetAverageRatingForProduct calculates average rating for a product
 func (dao *CustomerReviewDAO) GetAverageRatingForProduct(ctx context.Context, productID int) (float64, error) {
 	pipeline := []bson.M{
@@ -204,3 +246,74 @@ func (dao *CustomerReviewDAO) GetAverageRatingForProduct(ctx context.Context, pr
 
 	return 0, nil
 }
+
+// ProductRatingSummary holds the aggregated review statistics of a single product
+type ProductRatingSummary struct {
+	ProductID     int
+	AverageRating float64
+	ReviewCount   int64
+	// Histogram counts reviews per star (1-5), with ratings rounded to the nearest star
+	Histogram map[int]int64
+}
+
+// GetRatingSummariesForProducts computes rating summaries for many products in a single aggregation
+func (dao *CustomerReviewDAO) GetRatingSummariesForProducts(ctx context.Context, productIDs []int) (map[int]*ProductRatingSummary, error) {
+	summaries := make(map[int]*ProductRatingSummary, len(productIDs))
+	if len(productIDs) == 0 {
+		return summaries, nil
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"product_id": bson.M{"$in": productIDs}}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"product_id": "$product_id",
+				"star":       bson.M{"$round": bson.A{"$rating", 0}},
+			},
+			"count": bson.M{"$sum": 1},
+			"total": bson.M{"$sum": "$rating"},
+		}},
+		{"$group": bson.M{
+			"_id":   "$_id.product_id",
+			"count": bson.M{"$sum": "$count"},
+			"total": bson.M{"$sum": "$total"},
+			"stars": bson.M{"$push": bson.M{"star": "$_id.star", "count": "$count"}},
+		}},
+	}
+
+	cursor, err := dao.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var result struct {
+			ProductID int     `bson:"_id"`
+			Count     int64   `bson:"count"`
+			Total     float64 `bson:"total"`
+			Stars     []struct {
+				Star  float64 `bson:"star"`
+				Count int64   `bson:"count"`
+			} `bson:"stars"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+
+		summary := &ProductRatingSummary{
+			ProductID:   result.ProductID,
+			ReviewCount: result.Count,
+			Histogram:   make(map[int]int64, 5),
+		}
+		if result.Count > 0 {
+			summary.AverageRating = result.Total / float64(result.Count)
+		}
+		for _, star := range result.Stars {
+			summary.Histogram[int(star.Star)] += star.Count
+		}
+		summaries[result.ProductID] = summary
+	}
+
+	return summaries, cursor.Err()
+}
@@ -0,0 +1,16 @@
+package dao
+
+import (
+	"errors"
+	"petopia-be/apperrors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// wrapNotFound turns a missing document into a NotFound domain error and leaves other errors untouched
+func wrapNotFound(err error, message string) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return apperrors.Wrap(apperrors.ErrNotFound, message, err)
+	}
+	return err
+}
@@ -43,7 +43,7 @@ func (dao *ProductBrandDAO) GetProductBrandByID(ctx context.Context, id primitiv
 	var brand mongo_models.ProductBrand
 	err := dao.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&brand)
 	if err != nil {
-		return nil, err
+		return nil, wrapNotFound(err, "brand not found")
 	}
 	return &brand, nil
 }
@@ -53,7 +53,7 @@ func (dao *ProductBrandDAO) GetProductBrandByBrandID(ctx context.Context, brandI
 	var brand mongo_models.ProductBrand
 	err := dao.collection.FindOne(ctx, bson.M{"brand_id": brandID}).Decode(&brand)
 	if err != nil {
-		return nil, err
+		return nil, wrapNotFound(err, "brand not found")
 	}
 	return &brand, nil
 }
@@ -50,7 +50,7 @@ func (dao *ProductDetailsDAO) GetProductDetailsByID(ctx context.Context, id prim
 	var product mongo_models.ProductDetails
 	err := dao.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&product)
 	if err != nil {
-		return nil, err
+		return nil, wrapNotFound(err, "product not found")
 	}
 	return &product, nil
 }
@@ -60,7 +60,7 @@ func (dao *ProductDetailsDAO) GetProductDetailsByProductID(ctx context.Context,
 	var product mongo_models.ProductDetails
 	err := dao.collection.FindOne(ctx, bson.M{"product_id": productID}).Decode(&product)
 	if err != nil {
-		return nil, err
+		return nil, wrapNotFound(err, "product not found")
 	}
 	return &product, nil
 }
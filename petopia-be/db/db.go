@@ -16,17 +16,14 @@ func Connect() (*gorm.DB, error) {
 		os.Getenv("DB_NAME"),
 	)
 	fmt.Println("DSN:", dsn)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// Translate driver errors so constraint violations surface as gorm.ErrDuplicatedKey
+	// and gorm.ErrForeignKeyViolated
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
 
-	// // Run migrations
-	// err = db.AutoMigrate(&dao.Product{})
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	fmt.Println("Connected to PostgreSQL database using GORM and migrated the Product table!")
+	// Schema changes are applied by Migrator from the migrations directory
+	fmt.Println("Connected to PostgreSQL database using GORM!")
 	return db, nil
 }
@@ -0,0 +1,436 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MigrationLockID is the Postgres advisory lock key held while migrations run, so replicas
+// starting together apply each migration once
+const MigrationLockID int64 = 7_415_392_001
+
+// migrationFilePattern matches migration file names such as 0001_create_products_table.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// createSchemaMigrations creates the table recording applied migrations
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+// Migration is a versioned pair of up and down scripts
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// MigrationStatus describes a known or applied migration
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+
+	// Modified reports that the up script changed after it was applied
+	Modified bool
+
+	// Missing reports an applied version that has no migration files
+	Missing bool
+}
+
+// appliedMigration is a row of schema_migrations
+type appliedMigration struct {
+	version   int64
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Migrator applies the SQL migrations of a file system to a Postgres database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator loads and checks the migrations in files
+func NewMigrator(gormDB *gorm.DB, files fs.FS) (*Migrator, error) {
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := loadMigrations(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: sqlDB, migrations: migrations}, nil
+}
+
+// loadMigrations reads every migration in files ordered by version. Each version must have
+// exactly one up and one down script.
+func loadMigrations(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %v", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(files, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files named %s and %s", version, migration.Name, match[2])
+		}
+
+		script := &migration.Up
+		if match[3] == "down" {
+			script = &migration.Down
+		}
+		if *script != "" {
+			return nil, fmt.Errorf("migration %d has more than one %s script", version, match[3])
+		}
+		*script = string(content)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down script", migration.Version, migration.Name)
+		}
+		sum := sha256.Sum256([]byte(migration.Up))
+		migration.Checksum = hex.EncodeToString(sum[:])
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.latestVersion())
+}
+
+// Down reverts the given number of most recently applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("down needs a positive number of steps, got %d", steps)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(applied) - 1; i >= 0 && steps > 0; i, steps = i-1, steps-1 {
+			if err := m.revert(ctx, conn, m.find(applied[i].version)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// To applies pending migrations up to and including version and reverts applied migrations
+// above it. Version 0 reverts every migration.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(applied) - 1; i >= 0 && applied[i].version > version; i-- {
+			if err := m.revert(ctx, conn, m.find(applied[i].version)); err != nil {
+				return err
+			}
+		}
+
+		appliedVersions := make(map[int64]bool, len(applied))
+		for _, row := range applied {
+			appliedVersions[row.version] = true
+		}
+		for i := range m.migrations {
+			migration := &m.migrations[i]
+			if migration.Version > version || appliedVersions[migration.Version] {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Status lists every known and applied migration ordered by version
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.loadApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		appliedByVersion := make(map[int64]appliedMigration, len(applied))
+		for _, row := range applied {
+			appliedByVersion[row.version] = row
+			if m.find(row.version) == nil {
+				appliedAt := row.appliedAt
+				statuses = append(statuses, MigrationStatus{
+					Version:   row.version,
+					Name:      row.name,
+					Applied:   true,
+					AppliedAt: &appliedAt,
+					Missing:   true,
+				})
+			}
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if row, ok := appliedByVersion[migration.Version]; ok {
+				appliedAt := row.appliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = row.checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock. The lock is
+// session scoped, so it is taken and released on the same connection fn uses.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", MigrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %v", err)
+	}
+	defer func() {
+		// Use a fresh context so the lock is released even when ctx is done
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", MigrationLockID); err != nil {
+			log.Printf("Error releasing migration lock: %v", err)
+		}
+	}()
+
+	if err := m.adoptGolangMigrate(ctx, conn); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, createSchemaMigrations); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %v", err)
+	}
+
+	return fn(conn)
+}
+
+// adoptGolangMigrate converts a schema_migrations table written by golang-migrate, which the
+// migrations were previously applied with, into the runner's own table. Every migration up to
+// the recorded version is marked applied with the checksum of its current up script.
+func (m *Migrator) adoptGolangMigrate(ctx context.Context, conn *sql.Conn) error {
+	var legacy bool
+	err := conn.QueryRowContext(ctx, `SELECT EXISTS (
+    SELECT 1 FROM information_schema.columns
+    WHERE table_schema = current_schema() AND table_name = 'schema_migrations' AND column_name = 'dirty'
+)`).Scan(&legacy)
+	if err != nil || !legacy {
+		return err
+	}
+
+	var version int64
+	var dirty bool
+	err = conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("golang-migrate left migration %d dirty; fix the schema and clear the dirty flag first", version)
+	}
+
+	return inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DROP TABLE schema_migrations"); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, createSchemaMigrations); err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			_, err := tx.ExecContext(ctx,
+				"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+				migration.Version, migration.Name, migration.Checksum)
+			if err != nil {
+				return err
+			}
+		}
+		log.Printf("Adopted golang-migrate schema_migrations at version %d", version)
+		return nil
+	})
+}
+
+// loadApplied returns the applied migrations ordered by version
+func (m *Migrator) loadApplied(ctx context.Context, conn *sql.Conn) ([]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applied []appliedMigration
+	for rows.Next() {
+		var row appliedMigration
+		if err := rows.Scan(&row.version, &row.name, &row.checksum, &row.appliedAt); err != nil {
+			return nil, err
+		}
+		applied = append(applied, row)
+	}
+	return applied, rows.Err()
+}
+
+// verify returns the applied migrations after checking that each still has its files and
+// that its up script has not changed since it was applied
+func (m *Migrator) verify(ctx context.Context, conn *sql.Conn) ([]appliedMigration, error) {
+	applied, err := m.loadApplied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range applied {
+		migration := m.find(row.version)
+		if migration == nil {
+			return nil, fmt.Errorf("applied migration %d_%s has no migration files", row.version, row.name)
+		}
+		if migration.Checksum != row.checksum {
+			return nil, fmt.Errorf("migration %d_%s was modified after it was applied", row.version, row.name)
+		}
+	}
+	return applied, nil
+}
+
+// apply runs a migration's up script and records it in one transaction
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	err := inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %d_%s: %v", migration.Version, migration.Name, err)
+	}
+
+	log.Printf("Applied migration %d_%s", migration.Version, migration.Name)
+	return nil
+}
+
+// revert runs a migration's down script and removes its record in one transaction
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	err := inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revert migration %d_%s: %v", migration.Version, migration.Name, err)
+	}
+
+	log.Printf("Reverted migration %d_%s", migration.Version, migration.Name)
+	return nil
+}
+
+// inTransaction runs fn in a transaction on conn, committing when it succeeds
+func inTransaction(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// find returns the migration with a version, or nil if there is none
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// latestVersion returns the highest known version, or 0 without migrations
+func (m *Migrator) latestVersion() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProductTextIndexName is the name of the weighted full-text index on products
+const ProductTextIndexName = "product_text_search"
+
+// MongoMigrationsCollection records the versions of the applied MongoDB migrations
+const MongoMigrationsCollection = "migrations"
+
+// MongoMigration is a versioned change to MongoDB collections. Migrations must be idempotent:
+// replicas starting together may run the same pending migration concurrently.
+type MongoMigration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, database *mongo.Database) error
+}
+
+// mongoMigrationRecord is a document of the migrations collection
+type mongoMigrationRecord struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// mongoMigrations lists every MongoDB migration in version order. Append new migrations;
+// never change one that has been released.
+func mongoMigrations() []MongoMigration {
+	return []MongoMigration{
+		{Version: 1, Name: "create_product_indexes", Up: createProductIndexes},
+		{Version: 2, Name: "create_brand_indexes", Up: createBrandIndexes},
+		{Version: 3, Name: "create_review_indexes", Up: createIndexes("reviews", []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "created_at", Value: -1}},
+				Options: options.Index().SetName("product_id_created_at"),
+			},
+		})},
+		{Version: 4, Name: "add_schema_validators", Up: addSchemaValidators},
+	}
+}
+
+// MigrateMongo applies every pending MongoDB migration in version order and records it in
+// the migrations collection
+func MigrateMongo() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	database := GetMongoDatabase()
+	records := database.Collection(MongoMigrationsCollection)
+
+	cursor, err := records.Find(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("failed to read MongoDB migrations: %v", err)
+	}
+	var applied []mongoMigrationRecord
+	if err := cursor.All(ctx, &applied); err != nil {
+		return fmt.Errorf("failed to read MongoDB migrations: %v", err)
+	}
+
+	appliedVersions := make(map[int]bool, len(applied))
+	for _, record := range applied {
+		appliedVersions[record.Version] = true
+	}
+
+	for _, migration := range mongoMigrations() {
+		if appliedVersions[migration.Version] {
+			continue
+		}
+		if err := migration.Up(ctx, database); err != nil {
+			return fmt.Errorf("failed to apply MongoDB migration %d_%s: %v", migration.Version, migration.Name, err)
+		}
+
+		record := mongoMigrationRecord{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}
+		if _, err := records.InsertOne(ctx, record); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to record MongoDB migration %d_%s: %v", migration.Version, migration.Name, err)
+		}
+		log.Printf("Applied MongoDB migration %d_%s", migration.Version, migration.Name)
+	}
+
+	return nil
+}
+
+// createIndexes returns a migration step that creates indexes on a collection. Creating an
+// index that already exists with the same name and keys does nothing.
+func createIndexes(collection string, indexes []mongo.IndexModel) func(ctx context.Context, database *mongo.Database) error {
+	return func(ctx context.Context, database *mongo.Database) error {
+		_, err := database.Collection(collection).Indexes().CreateMany(ctx, indexes)
+		return err
+	}
+}
+
+// createProductIndexes creates the product indexes once every product has a unique product_id
+func createProductIndexes(ctx context.Context, database *mongo.Database) error {
+	if err := checkProductIDs(ctx, database); err != nil {
+		return err
+	}
+
+	return createIndexes("products", []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "product_id", Value: 1}},
+			Options: options.Index().SetName("product_id_unique").SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "product_name", Value: "text"},
+				{Key: "description", Value: "text"},
+				{Key: "brand_name", Value: "text"},
+				{Key: "category", Value: "text"},
+			},
+			Options: options.Index().
+				SetName(ProductTextIndexName).
+				SetWeights(bson.D{
+					{Key: "product_name", Value: 10},
+					{Key: "brand_name", Value: 5},
+					{Key: "category", Value: 3},
+					{Key: "description", Value: 1},
+				}),
+		},
+		{
+			Keys:    bson.D{{Key: "category", Value: 1}, {Key: "availability", Value: 1}},
+			Options: options.Index().SetName("category_availability"),
+		},
+	})(ctx, database)
+}
+
+// createBrandIndexes makes brand_id unique. Brands without a brand_id are left out of the index.
+// Brands were seeded on every start before the index existed; migrations never change catalog
+// data, so brands sharing a brand_id fail the migration until they are resolved by hand.
+func createBrandIndexes(ctx context.Context, database *mongo.Database) error {
+	brands := database.Collection("brands")
+
+	cursor, err := brands.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"brand_id": bson.M{"$gt": 0}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$brand_id",
+			"ids":   bson.M{"$push": "$_id"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	})
+	if err != nil {
+		return err
+	}
+	var duplicates []struct {
+		BrandID int                  `bson:"_id"`
+		IDs     []primitive.ObjectID `bson:"ids"`
+	}
+	if err := cursor.All(ctx, &duplicates); err != nil {
+		return err
+	}
+
+	if len(duplicates) > 0 {
+		conflicts := make([]string, len(duplicates))
+		for i, duplicate := range duplicates {
+			conflicts[i] = fmt.Sprintf("brand_id %d shared by %s", duplicate.BrandID, joinObjectIDs(duplicate.IDs))
+		}
+		return fmt.Errorf("brands need a unique brand_id before the index can be built (%s); "+
+			"remove the extra brands or give them another brand_id", strings.Join(conflicts, "; "))
+	}
+
+	return createIndexes("brands", []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "brand_id", Value: 1}},
+			Options: options.Index().
+				SetName("brand_id_unique").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"brand_id": bson.M{"$gt": 0}}),
+		},
+	})(ctx, database)
+}
+
+// addSchemaValidators attaches the $jsonSchema validators to their collections, creating
+// collections that do not exist yet. Validation is moderate so documents written before
+// the validators existed can still be updated.
+func addSchemaValidators(ctx context.Context, database *mongo.Database) error {
+	existing, err := database.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		exists[name] = true
+	}
+
+	for collection, schema := range mongoSchemas() {
+		validator := bson.M{"$jsonSchema": schema}
+		if !exists[collection] {
+			opts := options.CreateCollection().
+				SetValidator(validator).
+				SetValidationLevel("moderate").
+				SetValidationAction("error")
+			err := database.CreateCollection(ctx, collection, opts)
+			if err == nil {
+				continue
+			}
+			if !isNamespaceExists(err) {
+				return err
+			}
+		}
+
+		err := database.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: collection},
+			{Key: "validator", Value: validator},
+			{Key: "validationLevel", Value: "moderate"},
+			{Key: "validationAction", Value: "error"},
+		}).Err()
+		if err != nil {
+			return fmt.Errorf("failed to set validator on %s: %v", collection, err)
+		}
+	}
+	return nil
+}
+
+// isNamespaceExists reports whether err is MongoDB's NamespaceExists error, returned when a
+// concurrent migration created the collection first
+func isNamespaceExists(err error) bool {
+	var commandErr mongo.CommandError
+	return errors.As(err, &commandErr) && commandErr.Code == 48
+}
@@ -0,0 +1,85 @@
+package db
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// BSON types accepted for Go fields. Go ints are stored as int or long depending on their
+// value, and nil slices and maps are stored as null.
+var (
+	intTypes       = bson.A{"int", "long"}
+	numberTypes    = bson.A{"int", "long", "double", "decimal"}
+	nullableArray  = bson.A{"array", "null"}
+	nullableObject = bson.A{"object", "null"}
+)
+
+// mongoSchemas returns the $jsonSchema validator of each collection, matching the
+// ProductDetails, ProductBrand and CustomerReview models. Fields not listed are allowed.
+func mongoSchemas() map[string]bson.M {
+	return map[string]bson.M{
+		"products": {
+			"bsonType": "object",
+			"required": bson.A{"product_id", "product_name", "price"},
+			"properties": bson.M{
+				"product_id":      bson.M{"bsonType": intTypes, "minimum": 1},
+				"product_name":    bson.M{"bsonType": "string", "minLength": 1},
+				"description":     bson.M{"bsonType": "string"},
+				"brand_id":        bson.M{"bsonType": intTypes, "minimum": 0},
+				"brand_name":      bson.M{"bsonType": "string"},
+				"seller_id":       bson.M{"bsonType": intTypes, "minimum": 0},
+				"category":        bson.M{"bsonType": "string"},
+				"item_dimensions": bson.M{"bsonType": nullableObject},
+				"price":           bson.M{"bsonType": numberTypes, "minimum": 0},
+				"discount":        bson.M{"bsonType": numberTypes, "minimum": 0, "maximum": 100},
+				"availability":    bson.M{"bsonType": "bool"},
+				"created_at":      bson.M{"bsonType": "date"},
+				"updated_at":      bson.M{"bsonType": "date"},
+				"is_deleted":      bson.M{"bsonType": "bool"},
+				"deleted_at":      bson.M{"bsonType": bson.A{"date", "null"}},
+				"deleted_by":      bson.M{"bsonType": intTypes},
+			},
+		},
+		"brands": {
+			"bsonType": "object",
+			"required": bson.A{"brand_id", "brand_name"},
+			"properties": bson.M{
+				"brand_id":         bson.M{"bsonType": intTypes, "minimum": 0},
+				"brand_name":       bson.M{"bsonType": "string", "minLength": 1},
+				"description":      bson.M{"bsonType": "string"},
+				"logo_url":         bson.M{"bsonType": "string"},
+				"website":          bson.M{"bsonType": "string"},
+				"country":          bson.M{"bsonType": "string"},
+				"established_year": bson.M{"bsonType": intTypes},
+				"categories":       bson.M{"bsonType": nullableArray, "items": bson.M{"bsonType": "string"}},
+				"is_active":        bson.M{"bsonType": "bool"},
+				"created_at":       bson.M{"bsonType": "date"},
+				"updated_at":       bson.M{"bsonType": "date"},
+			},
+		},
+		"reviews": {
+			"bsonType": "object",
+			"required": bson.A{"product_id", "customer_id", "rating"},
+			"properties": bson.M{
+				"product_id":        bson.M{"bsonType": intTypes, "minimum": 1},
+				"customer_id":       bson.M{"bsonType": intTypes},
+				"customer_name":     bson.M{"bsonType": "string"},
+				"rating":            bson.M{"bsonType": numberTypes, "minimum": 1, "maximum": 5},
+				"title":             bson.M{"bsonType": "string"},
+				"comment":           bson.M{"bsonType": "string"},
+				"images":            bson.M{"bsonType": nullableArray, "items": bson.M{"bsonType": "string"}},
+				"verified_purchase": bson.M{"bsonType": "bool"},
+				"helpful_votes":     bson.M{"bsonType": intTypes, "minimum": 0},
+				"filters":           bson.M{"bsonType": nullableObject},
+				"pet_info": bson.M{
+					"bsonType": "object",
+					"properties": bson.M{
+						"pet_type":  bson.M{"bsonType": "string"},
+						"pet_breed": bson.M{"bsonType": "string"},
+						"pet_age":   bson.M{"bsonType": intTypes, "minimum": 0},
+						"pet_size":  bson.M{"bsonType": "string"},
+					},
+				},
+				"created_at": bson.M{"bsonType": "date"},
+				"updated_at": bson.M{"bsonType": "date"},
+			},
+		},
+	}
+}
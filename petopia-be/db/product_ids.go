@@ -0,0 +1,306 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProductIDSequence is the counters document that assigns product_id values
+const ProductIDSequence = "product_id"
+
+// ErrProductIDConflicts is returned when products share a product_id and are not exact copies.
+// An operator has to decide which product keeps the id.
+var ErrProductIDConflicts = errors.New("products share a product_id and need to be resolved by hand")
+
+// productVersionFields are left out when comparing copies of a product
+var productVersionFields = []string{"_id", "created_at", "updated_at"}
+
+// ProductIDConflict lists the products sharing a product_id that are not exact copies
+type ProductIDConflict struct {
+	ProductID int64
+	IDs       []primitive.ObjectID
+}
+
+// ProductIDRepair describes what keeps the unique product_id index from being built and how
+// RepairProductIDs resolves it. Products referenced by carts, inventory or reviews keep their
+// product_id; conflicts are only reported.
+type ProductIDRepair struct {
+	// Remove lists later exact copies of a product that keeps its product_id
+	Remove []primitive.ObjectID
+
+	// Convert maps products whose product_id is a numeric string to that number
+	Convert map[primitive.ObjectID]int64
+
+	// Assign lists products without a usable product_id, which get the next counter value
+	Assign []primitive.ObjectID
+
+	// Conflicts lists product_ids shared by products that differ
+	Conflicts []ProductIDConflict
+}
+
+// Empty reports whether the products need no repair
+func (r *ProductIDRepair) Empty() bool {
+	return len(r.Remove) == 0 && len(r.Convert) == 0 && len(r.Assign) == 0 && len(r.Conflicts) == 0
+}
+
+// String summarises the repair with the affected product _ids
+func (r *ProductIDRepair) String() string {
+	var parts []string
+	for _, conflict := range r.Conflicts {
+		parts = append(parts, fmt.Sprintf("product_id %d shared by %s", conflict.ProductID, joinObjectIDs(conflict.IDs)))
+	}
+	if len(r.Remove) > 0 {
+		parts = append(parts, "exact copies "+joinObjectIDs(r.Remove))
+	}
+	if len(r.Convert) > 0 {
+		ids := make([]primitive.ObjectID, 0, len(r.Convert))
+		for id := range r.Convert {
+			ids = append(ids, id)
+		}
+		sortObjectIDs(ids)
+		parts = append(parts, "numeric string product_id "+joinObjectIDs(ids))
+	}
+	if len(r.Assign) > 0 {
+		parts = append(parts, "missing or invalid product_id "+joinObjectIDs(r.Assign))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// checkProductIDs fails when products would break the unique product_id index. Migrations never
+// change catalog data, so the products are listed for the repair-product-ids command instead.
+func checkProductIDs(ctx context.Context, database *mongo.Database) error {
+	repair, err := PlanProductIDRepair(ctx, database)
+	if err != nil {
+		return err
+	}
+	if repair.Empty() {
+		return nil
+	}
+	return fmt.Errorf("products need a product_id repair before the unique index can be built (%s); "+
+		"review it with the repair-product-ids command and apply it with repair-product-ids --apply", repair)
+}
+
+// PlanProductIDRepair reads every product and plans the repair of their product_ids
+func PlanProductIDRepair(ctx context.Context, database *mongo.Database) (*ProductIDRepair, error) {
+	cursor, err := database.Collection("products").Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var products []bson.M
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+	return planProductIDRepair(products), nil
+}
+
+// planProductIDRepair plans the repair of products given in _id order. For each product_id
+// shared by several products, the oldest keeps it and later exact copies are removed; any
+// copy that differs, for example in is_deleted, price or seller, makes it a conflict.
+func planProductIDRepair(products []bson.M) *ProductIDRepair {
+	repair := &ProductIDRepair{Convert: make(map[primitive.ObjectID]int64)}
+
+	byProductID := make(map[int64][]bson.M)
+	var productIDs []int64
+	for _, product := range products {
+		id, _ := product["_id"].(primitive.ObjectID)
+		productID, ok := numericProductID(product["product_id"])
+		if !ok {
+			if converted, ok := stringProductID(product["product_id"]); ok {
+				repair.Convert[id] = converted
+				productID = converted
+			} else {
+				repair.Assign = append(repair.Assign, id)
+				continue
+			}
+		}
+		if _, seen := byProductID[productID]; !seen {
+			productIDs = append(productIDs, productID)
+		}
+		byProductID[productID] = append(byProductID[productID], product)
+	}
+
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+	for _, productID := range productIDs {
+		group := byProductID[productID]
+		if len(group) == 1 {
+			continue
+		}
+
+		kept := group[0]
+		var copies []primitive.ObjectID
+		conflict := false
+		for _, product := range group[1:] {
+			id, _ := product["_id"].(primitive.ObjectID)
+			if !sameProduct(kept, product) {
+				conflict = true
+				break
+			}
+			copies = append(copies, id)
+		}
+
+		if !conflict {
+			repair.Remove = append(repair.Remove, copies...)
+			for _, id := range copies {
+				delete(repair.Convert, id)
+			}
+			continue
+		}
+
+		ids := make([]primitive.ObjectID, len(group))
+		for i, product := range group {
+			ids[i], _ = product["_id"].(primitive.ObjectID)
+			delete(repair.Convert, ids[i])
+		}
+		repair.Conflicts = append(repair.Conflicts, ProductIDConflict{ProductID: productID, IDs: ids})
+	}
+
+	return repair
+}
+
+// RepairProductIDs applies a planned repair: exact copies are removed, numeric string ids become
+// numbers and products without a usable id get the next counter value. Conflicts are left
+// untouched and reported with ErrProductIDConflicts.
+func RepairProductIDs(ctx context.Context, database *mongo.Database, repair *ProductIDRepair) error {
+	products := database.Collection("products")
+	counters := database.Collection("counters")
+
+	if len(repair.Remove) > 0 {
+		if _, err := products.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": repair.Remove}}); err != nil {
+			return err
+		}
+	}
+
+	for id, productID := range repair.Convert {
+		_, err := products.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"product_id": productID}})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := SyncProductIDCounter(ctx, database); err != nil {
+		return err
+	}
+	for _, id := range repair.Assign {
+		var counter struct {
+			Seq int64 `bson:"seq"`
+		}
+		err := counters.FindOneAndUpdate(ctx,
+			bson.M{"_id": ProductIDSequence},
+			bson.M{"$inc": bson.M{"seq": 1}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&counter)
+		if err != nil {
+			return err
+		}
+		_, err = products.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"product_id": counter.Seq}})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(repair.Conflicts) > 0 {
+		return ErrProductIDConflicts
+	}
+	return nil
+}
+
+// SyncProductIDCounter moves the product_id sequence past the highest numeric product_id
+// already stored, e.g. by seeding or imports, so assigned ids never collide with existing ones
+func SyncProductIDCounter(ctx context.Context, database *mongo.Database) error {
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "product_id", Value: -1}}).
+		SetProjection(bson.M{"product_id": 1})
+
+	var highest bson.M
+	err := database.Collection("products").
+		FindOne(ctx, bson.M{"product_id": bson.M{"$type": "number"}}, opts).
+		Decode(&highest)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	productID, _ := numericProductID(highest["product_id"])
+
+	_, err = database.Collection("counters").UpdateOne(ctx,
+		bson.M{"_id": ProductIDSequence},
+		bson.M{"$max": bson.M{"seq": productID}},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+// numericProductID returns a stored product_id that is a positive whole number
+func numericProductID(value interface{}) (int64, bool) {
+	var number float64
+	switch v := value.(type) {
+	case int32:
+		number = float64(v)
+	case int64:
+		number = float64(v)
+	case float64:
+		number = v
+	default:
+		return 0, false
+	}
+	if number <= 0 || number != math.Trunc(number) || number > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(number), true
+}
+
+// stringProductID returns a product_id stored as a string of a positive integer
+func stringProductID(value interface{}) (int64, bool) {
+	text, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+	productID, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	if err != nil || productID <= 0 {
+		return 0, false
+	}
+	return productID, true
+}
+
+// sameProduct reports whether two products are equal apart from their _id and timestamps,
+// treating a numeric string product_id like the number
+func sameProduct(a, b bson.M) bool {
+	normalize := func(product bson.M) bson.M {
+		fields := make(bson.M, len(product))
+		for key, value := range product {
+			fields[key] = value
+		}
+		for _, key := range productVersionFields {
+			delete(fields, key)
+		}
+		if productID, ok := numericProductID(fields["product_id"]); ok {
+			fields["product_id"] = productID
+		} else if productID, ok := stringProductID(fields["product_id"]); ok {
+			fields["product_id"] = productID
+		}
+		return fields
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// joinObjectIDs formats ids as a comma separated list of hex strings
+func joinObjectIDs(ids []primitive.ObjectID) string {
+	hexIDs := make([]string, len(ids))
+	for i, id := range ids {
+		hexIDs[i] = id.Hex()
+	}
+	return strings.Join(hexIDs, ", ")
+}
+
+// sortObjectIDs orders ids by creation
+func sortObjectIDs(ids []primitive.ObjectID) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Hex() < ids[j].Hex() })
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// testObjectID returns a fixed ObjectID that sorts by n
+func testObjectID(n byte) primitive.ObjectID {
+	var id primitive.ObjectID
+	id[11] = n
+	return id
+}
+
+// testProduct returns a stored product with the given _id and product_id
+func testProduct(n byte, productID interface{}, fields bson.M) bson.M {
+	product := bson.M{
+		"_id":          testObjectID(n),
+		"product_name": "Dog Bed",
+		"price":        45.0,
+		"seller_id":    int32(1),
+		"is_deleted":   false,
+		"created_at":   primitive.NewDateTimeFromTime(time.Unix(int64(n), 0)),
+		"updated_at":   primitive.NewDateTimeFromTime(time.Unix(int64(n), 0)),
+	}
+	if productID != nil {
+		product["product_id"] = productID
+	}
+	for key, value := range fields {
+		product[key] = value
+	}
+	return product
+}
+
+func TestPlanProductIDRepair(t *testing.T) {
+	tests := []struct {
+		name     string
+		products []bson.M
+		want     *ProductIDRepair
+	}{
+		{
+			name: "unique ids",
+			products: []bson.M{
+				testProduct(1, int32(101), nil),
+				testProduct(2, int64(102), nil),
+				testProduct(3, float64(103), nil),
+			},
+			want: &ProductIDRepair{},
+		},
+		{
+			name: "exact copies are removed",
+			products: []bson.M{
+				testProduct(1, int32(101), nil),
+				testProduct(2, int32(101), nil),
+				testProduct(3, int64(101), nil),
+			},
+			want: &ProductIDRepair{Remove: []primitive.ObjectID{testObjectID(2), testObjectID(3)}},
+		},
+		{
+			name: "deleted copy conflicts",
+			products: []bson.M{
+				testProduct(1, int32(101), bson.M{"is_deleted": true}),
+				testProduct(2, int32(101), nil),
+			},
+			want: &ProductIDRepair{Conflicts: []ProductIDConflict{
+				{ProductID: 101, IDs: []primitive.ObjectID{testObjectID(1), testObjectID(2)}},
+			}},
+		},
+		{
+			name: "different price conflicts",
+			products: []bson.M{
+				testProduct(1, int32(101), nil),
+				testProduct(2, int32(101), nil),
+				testProduct(3, int32(101), bson.M{"price": 40.0}),
+			},
+			want: &ProductIDRepair{Conflicts: []ProductIDConflict{
+				{ProductID: 101, IDs: []primitive.ObjectID{testObjectID(1), testObjectID(2), testObjectID(3)}},
+			}},
+		},
+		{
+			name: "different seller conflicts",
+			products: []bson.M{
+				testProduct(1, int32(101), nil),
+				testProduct(2, int32(101), bson.M{"seller_id": int32(2)}),
+			},
+			want: &ProductIDRepair{Conflicts: []ProductIDConflict{
+				{ProductID: 101, IDs: []primitive.ObjectID{testObjectID(1), testObjectID(2)}},
+			}},
+		},
+		{
+			name: "missing, zero, negative, fractional and text ids are assigned",
+			products: []bson.M{
+				testProduct(1, nil, nil),
+				testProduct(2, int32(0), nil),
+				testProduct(3, int64(-4), nil),
+				testProduct(4, 101.5, nil),
+				testProduct(5, "dog-bed", nil),
+			},
+			want: &ProductIDRepair{Assign: []primitive.ObjectID{
+				testObjectID(1), testObjectID(2), testObjectID(3), testObjectID(4), testObjectID(5),
+			}},
+		},
+		{
+			name: "numeric string ids are converted",
+			products: []bson.M{
+				testProduct(1, int32(101), nil),
+				testProduct(2, "102", nil),
+			},
+			want: &ProductIDRepair{Convert: map[primitive.ObjectID]int64{testObjectID(2): 102}},
+		},
+		{
+			name: "numeric string copy of a numeric id is removed",
+			products: []bson.M{
+				testProduct(1, int32(101), nil),
+				testProduct(2, "101", nil),
+			},
+			want: &ProductIDRepair{Remove: []primitive.ObjectID{testObjectID(2)}},
+		},
+		{
+			name: "numeric string id that differs conflicts",
+			products: []bson.M{
+				testProduct(1, "101", nil),
+				testProduct(2, int32(101), bson.M{"product_name": "Cat Bed"}),
+			},
+			want: &ProductIDRepair{Conflicts: []ProductIDConflict{
+				{ProductID: 101, IDs: []primitive.ObjectID{testObjectID(1), testObjectID(2)}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planProductIDRepair(tt.products)
+			if tt.want.Convert == nil {
+				tt.want.Convert = map[primitive.ObjectID]int64{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planProductIDRepair() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumericProductID(t *testing.T) {
+	tests := []struct {
+		value  interface{}
+		want   int64
+		wantOK bool
+	}{
+		{int32(101), 101, true},
+		{int64(102), 102, true},
+		{float64(103), 103, true},
+		{103.5, 0, false},
+		{int32(0), 0, false},
+		{int64(-1), 0, false},
+		{"104", 0, false},
+		{nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := numericProductID(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("numericProductID(%#v) = %d, %v, want %d, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
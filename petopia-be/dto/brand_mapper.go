@@ -0,0 +1,70 @@
+package dto
+
+import (
+	mongomodels "petopia-be/models/mongo"
+)
+
+// MapBrandToDTO converts a ProductBrand model to a BrandResponseDTO
+func MapBrandToDTO(brand *mongomodels.ProductBrand) *BrandResponseDTO {
+	if brand == nil {
+		return nil
+	}
+
+	return &BrandResponseDTO{
+		ID:              brand.ID.Hex(),
+		BrandID:         brand.BrandID,
+		BrandName:       brand.BrandName,
+		Description:     brand.Description,
+		LogoURL:         brand.LogoURL,
+		Website:         brand.Website,
+		Country:         brand.Country,
+		EstablishedYear: brand.EstablishedYear,
+		Categories:      brand.Categories,
+		IsActive:        brand.IsActive,
+		CreatedAt:       brand.CreatedAt,
+		UpdatedAt:       brand.UpdatedAt,
+	}
+}
+
+// MapBrandsToDTOs converts a slice of ProductBrand models to a slice of BrandResponseDTOs
+func MapBrandsToDTOs(brands []mongomodels.ProductBrand) []BrandResponseDTO {
+	brandDTOs := make([]BrandResponseDTO, len(brands))
+	for i, brand := range brands {
+		brandDTOs[i] = *MapBrandToDTO(&brand)
+	}
+	return brandDTOs
+}
+
+// MapBrandPointersToDTOs converts a slice of ProductBrand pointers (as returned by the DAO) to BrandResponseDTOs
+func MapBrandPointersToDTOs(brands []*mongomodels.ProductBrand) []BrandResponseDTO {
+	brandDTOs := make([]BrandResponseDTO, 0, len(brands))
+	for _, brand := range brands {
+		brandDTOs = append(brandDTOs, *MapBrandToDTO(brand))
+	}
+	return brandDTOs
+}
+
+// MapDTOToBrand converts a BrandRequestDTO to a ProductBrand model. A brand is active
+// unless the request sets is_active to false.
+func MapDTOToBrand(dto *BrandRequestDTO) *mongomodels.ProductBrand {
+	if dto == nil {
+		return nil
+	}
+
+	isActive := true
+	if dto.IsActive != nil {
+		isActive = *dto.IsActive
+	}
+
+	return &mongomodels.ProductBrand{
+		BrandID:         dto.BrandID,
+		BrandName:       dto.BrandName,
+		Description:     dto.Description,
+		LogoURL:         dto.LogoURL,
+		Website:         dto.Website,
+		Country:         dto.Country,
+		EstablishedYear: dto.EstablishedYear,
+		Categories:      dto.Categories,
+		IsActive:        isActive,
+	}
+}
@@ -0,0 +1,15 @@
+package dto
+
+// BrandRequestDTO represents the data transfer object for a brand request.
+// IsActive is a pointer so an omitted field can be told apart from false.
+type BrandRequestDTO struct {
+	BrandID         int      `json:"brand_id"`
+	BrandName       string   `json:"brand_name" binding:"required"`
+	Description     string   `json:"description"`
+	LogoURL         string   `json:"logo_url"`
+	Website         string   `json:"website"`
+	Country         string   `json:"country"`
+	EstablishedYear int      `json:"established_year"`
+	Categories      []string `json:"categories"`
+	IsActive        *bool    `json:"is_active"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// BrandResponseDTO represents the data transfer object for a brand response
+type BrandResponseDTO struct {
+	ID              string    `json:"id"`
+	BrandID         int       `json:"brand_id"`
+	BrandName       string    `json:"brand_name"`
+	Description     string    `json:"description"`
+	LogoURL         string    `json:"logo_url"`
+	Website         string    `json:"website"`
+	Country         string    `json:"country"`
+	EstablishedYear int       `json:"established_year"`
+	Categories      []string  `json:"categories"`
+	IsActive        bool      `json:"is_active"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
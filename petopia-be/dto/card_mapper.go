@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"petopia-be/cardvault"
+	pgmodels "petopia-be/models/postgres"
+)
+
+// MapCardToDTO converts a UserCard model to a CardResponseDTO
+func MapCardToDTO(card *pgmodels.UserCard) *CardResponseDTO {
+	if card == nil {
+		return nil
+	}
+
+	return &CardResponseDTO{
+		Token:        card.Token,
+		MaskedNumber: cardvault.Mask(card.Last4),
+		Last4:        card.Last4,
+		Brand:        card.Brand,
+		ExpiryMonth:  card.ExpiryMonth,
+		ExpiryYear:   card.ExpiryYear,
+		CreatedAt:    card.CreatedAt,
+		CreatedBy:    card.CreatedBy,
+	}
+}
+
+// MapCardsToDTOs converts a slice of UserCard models to a slice of CardResponseDTOs
+func MapCardsToDTOs(cards []pgmodels.UserCard) []CardResponseDTO {
+	cardDTOs := make([]CardResponseDTO, len(cards))
+	for i, card := range cards {
+		cardDTOs[i] = *MapCardToDTO(&card)
+	}
+	return cardDTOs
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "petopia-be/cardvault"
+
+// CardRequestDTO represents the data transfer object for saving a payment card. CardNumber
+// masks itself when printed so a logged request never reveals the number.
+type CardRequestDTO struct {
+	CardNumber  cardvault.CardNumber `json:"card_number" binding:"required"`
+	ExpiryMonth int                  `json:"expiry_month" binding:"required"`
+	ExpiryYear  int                  `json:"expiry_year" binding:"required"`
+}
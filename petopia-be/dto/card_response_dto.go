@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// CardResponseDTO represents the data transfer object for a saved payment card. Only the
+// masked number and display fields are returned; Token identifies the card in later requests.
+type CardResponseDTO struct {
+	Token        string    `json:"token"`
+	MaskedNumber string    `json:"masked_number"`
+	Last4        string    `json:"last4"`
+	Brand        string    `json:"brand"`
+	ExpiryMonth  int       `json:"expiry_month,omitempty"`
+	ExpiryYear   int       `json:"expiry_year,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    *int      `json:"created_by,omitempty"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// CartItemRequestDTO represents the data transfer object for adding a product to a cart
+type CartItemRequestDTO struct {
+	ProductID int `json:"product_id" binding:"required"`
+	Quantity  int `json:"quantity"`
+}
+
+// CartQuantityRequestDTO represents the data transfer object for changing a cart item quantity
+type CartQuantityRequestDTO struct {
+	Quantity int `json:"quantity" binding:"required"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+// CartResponseDTO represents a user's cart priced from the product catalog
+type CartResponseDTO struct {
+	UserID    int                   `json:"user_id"`
+	Items     []CartItemResponseDTO `json:"items"`
+	ItemCount int                   `json:"item_count"`
+	Total     float64               `json:"total"`
+}
+
+// CartItemResponseDTO represents one cart line. UnitPrice is the catalog price and SalePrice
+// the price after the discount percentage. Lines whose product is no longer available are
+// returned with Available false and excluded from the cart total.
+type CartItemResponseDTO struct {
+	ProductID   int     `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Discount    float64 `json:"discount"`
+	SalePrice   float64 `json:"sale_price"`
+	LineTotal   float64 `json:"line_total"`
+	Available   bool    `json:"available"`
+}
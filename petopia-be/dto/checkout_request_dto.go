@@ -0,0 +1,8 @@
+package dto
+
+// CheckoutRequestDTO represents the data transfer object for checking out a user's cart
+// to one of their addresses
+type CheckoutRequestDTO struct {
+	UserID    int `json:"user_id" binding:"required"`
+	AddressID int `json:"address_id" binding:"required"`
+}
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	mongomodels "petopia-be/models/mongo"
@@ -29,6 +31,9 @@ func MapProductToDTO(product *mongomodels.ProductDetails) *ProductResponseDTO {
 		Availability:   product.Availability,
 		CreatedAt:      product.CreatedAt,
 		UpdatedAt:      product.UpdatedAt,
+		IsDeleted:      product.IsDeleted,
+		DeletedAt:      product.DeletedAt,
+		DeletedBy:      product.DeletedBy,
 	}
 }
 
@@ -60,34 +65,85 @@ func MapDTOToProduct(dto *ProductRequestDTO, id ...string) *mongomodels.ProductD
 		Availability:   dto.Availability,
 	}
 
-	// Set times for new products
-	now := time.Now()
-	product.CreatedAt = now
-	product.UpdatedAt = now
-
-	// Set ID if provided
+	// Set ID if provided, otherwise set times for a new product.
+	// Existing products keep their created_at, which updates never touch.
 	if len(id) > 0 && id[0] != "" {
 		objectID, err := primitive.ObjectIDFromHex(id[0])
 		if err == nil {
 			product.ID = objectID
 		}
+	} else {
+		now := time.Now()
+		product.CreatedAt = now
+		product.UpdatedAt = now
 	}
 
 	return product
 }
 
+// MapProductToRequestDTO converts a ProductDetails model to the writable fields of a ProductRequestDTO
+func MapProductToRequestDTO(product *mongomodels.ProductDetails) *ProductRequestDTO {
+	if product == nil {
+		return nil
+	}
+
+	return &ProductRequestDTO{
+		ProductName:    product.ProductName,
+		Description:    product.Description,
+		BrandID:        product.BrandID,
+		BrandName:      product.BrandName,
+		SellerID:       product.SellerID,
+		Category:       product.Category,
+		ItemDimensions: product.ItemDimensions,
+		Price:          product.Price,
+		Discount:       product.Discount,
+		Availability:   product.Availability,
+	}
+}
+
+// ProductETag returns the entity tag of a product version, derived from its updated_at
+// at the millisecond precision MongoDB stores
+func ProductETag(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixMilli(), 10) + `"`
+}
+
+// ParseProductETag extracts the updated_at version from an entity tag produced by ProductETag
+func ParseProductETag(etag string) (time.Time, error) {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	millis, err := strconv.ParseInt(strings.Trim(etag, `"`), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(millis), nil
+}
+
 // CreatePaginatedResponse creates a PaginatedResponse from items, total count, page and limit
 func CreatePaginatedResponse(items interface{}, total, page, limit int64) *PaginatedResponse {
-	totalPages := int64(1)
-	if limit > 0 {
-		totalPages = (total + limit - 1) / limit // Ceiling division
+	return &PaginatedResponse{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages(total, limit),
 	}
+}
 
+// CreateCursorPaginatedResponse creates a cursor mode PaginatedResponse from items and the neighbouring page tokens
+func CreateCursorPaginatedResponse(items interface{}, total, limit int64, nextCursor, prevCursor string) *PaginatedResponse {
 	return &PaginatedResponse{
 		Items:      items,
 		Total:      total,
-		Page:       page,
 		Limit:      limit,
-		TotalPages: totalPages,
+		TotalPages: totalPages(total, limit),
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	}
+}
+
+// totalPages returns the number of pages of limit items needed for total items
+func totalPages(total, limit int64) int64 {
+	if limit <= 0 {
+		return 1
 	}
+	return (total + limit - 1) / limit // Ceiling division
 }
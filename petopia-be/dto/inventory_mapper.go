@@ -0,0 +1,32 @@
+package dto
+
+import (
+	pgmodels "petopia-be/models/postgres"
+)
+
+// MapInventoryItemsToDTO sums the stock rows of a product into an InventoryResponseDTO with one
+// location per row. Low stock flags are left for the caller to set.
+func MapInventoryItemsToDTO(productID int, items []pgmodels.InventoryItem) *InventoryResponseDTO {
+	inventory := &InventoryResponseDTO{
+		ProductID: productID,
+		Locations: make([]InventoryLocationResponseDTO, len(items)),
+	}
+
+	for i, item := range items {
+		inventory.Quantity += item.Quantity
+		inventory.Reserved += item.Reserved
+		inventory.Locations[i] = InventoryLocationResponseDTO{
+			ID:                item.ID,
+			SellerID:          item.SellerID,
+			InventoryLocation: item.InventoryLocation,
+			Quantity:          item.Quantity,
+			Reserved:          item.Reserved,
+			Available:         item.Available(),
+			UpdatedAt:         item.UpdatedAt,
+			UpdatedBy:         item.UpdatedBy,
+		}
+	}
+	inventory.Available = inventory.Quantity - inventory.Reserved
+
+	return inventory
+}
@@ -0,0 +1,14 @@
+package dto
+
+// InventoryAdjustRequestDTO represents the data transfer object for adding or removing stock a
+// seller holds at a location. Delta is negative to remove stock.
+type InventoryAdjustRequestDTO struct {
+	SellerID          int    `json:"seller_id" binding:"required"`
+	InventoryLocation string `json:"inventory_location" binding:"required"`
+	Delta             int    `json:"delta" binding:"required"`
+}
+
+// InventoryQuantityRequestDTO represents the data transfer object for reserving or releasing stock
+type InventoryQuantityRequestDTO struct {
+	Quantity int `json:"quantity" binding:"required"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// InventoryResponseDTO represents the stock of a product summed over its sellers and locations.
+// Available is the stock that is not reserved; LowStock is set when it is at or below
+// LowStockThreshold.
+type InventoryResponseDTO struct {
+	ProductID         int                            `json:"product_id"`
+	Quantity          int                            `json:"quantity"`
+	Reserved          int                            `json:"reserved"`
+	Available         int                            `json:"available"`
+	LowStock          bool                           `json:"low_stock"`
+	LowStockThreshold int                            `json:"low_stock_threshold"`
+	Locations         []InventoryLocationResponseDTO `json:"locations,omitempty"`
+}
+
+// InventoryLocationResponseDTO represents the stock a seller holds of a product at a location
+type InventoryLocationResponseDTO struct {
+	ID                int        `json:"id"`
+	SellerID          int        `json:"seller_id"`
+	InventoryLocation string     `json:"inventory_location"`
+	Quantity          int        `json:"quantity"`
+	Reserved          int        `json:"reserved"`
+	Available         int        `json:"available"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy         *int       `json:"updated_by,omitempty"`
+}
+
+// LowStockResponseDTO represents the products whose available stock is at or below a threshold
+type LowStockResponseDTO struct {
+	Threshold int                    `json:"threshold"`
+	Products  []InventoryResponseDTO `json:"products"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	pgmodels "petopia-be/models/postgres"
+)
+
+// MapOrderToDTO converts an Order model and its products to an OrderResponseDTO.
+// Line totals are left for the caller to price.
+func MapOrderToDTO(order *pgmodels.Order) *OrderResponseDTO {
+	if order == nil {
+		return nil
+	}
+
+	items := make([]OrderItemResponseDTO, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = OrderItemResponseDTO{
+			ID:             item.ID,
+			ProductID:      item.ProductID,
+			ProductName:    item.ProductName,
+			Quantity:       item.Quantity,
+			Price:          item.Price,
+			Discount:       item.Discount,
+			DeliveryStatus: item.DeliveryStatus,
+			ReturnStatus:   item.ReturnStatus,
+		}
+	}
+
+	return &OrderResponseDTO{
+		ID:         order.ID,
+		UserID:     order.UserID,
+		Address:    order.Address,
+		Items:      items,
+		TotalPrice: order.TotalPrice,
+		CreatedAt:  order.CreatedAt,
+		CreatedBy:  order.CreatedBy,
+	}
+}
@@ -0,0 +1,8 @@
+package dto
+
+// OrderItemStatusRequestDTO represents the data transfer object for moving an ordered product
+// to a new delivery or return status. Exactly one of the statuses is set.
+type OrderItemStatusRequestDTO struct {
+	DeliveryStatus string `json:"delivery_status,omitempty"`
+	ReturnStatus   string `json:"return_status,omitempty"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// OrderResponseDTO represents the data transfer object for an order response
+type OrderResponseDTO struct {
+	ID         int                    `json:"id"`
+	UserID     int                    `json:"user_id"`
+	Address    string                 `json:"address"`
+	Items      []OrderItemResponseDTO `json:"items"`
+	TotalPrice float64                `json:"total_price"`
+	CreatedAt  time.Time              `json:"created_at"`
+	CreatedBy  *int                   `json:"created_by,omitempty"`
+}
+
+// OrderItemResponseDTO represents one ordered product. ProductName, Price and Discount are the
+// name, unit price and discount percentage at checkout; LineTotal is the discounted price times
+// the quantity.
+type OrderItemResponseDTO struct {
+	ID             int     `json:"id"`
+	ProductID      int     `json:"product_id"`
+	ProductName    string  `json:"product_name"`
+	Quantity       int     `json:"quantity"`
+	Price          float64 `json:"price"`
+	Discount       float64 `json:"discount"`
+	LineTotal      float64 `json:"line_total"`
+	DeliveryStatus string  `json:"delivery_status"`
+	ReturnStatus   string  `json:"return_status"`
+}
@@ -0,0 +1,82 @@
+package dto
+
+// Bulk product actions
+const (
+	BulkActionSetPrice        = "set_price"
+	BulkActionSetDiscount     = "set_discount"
+	BulkActionSetAvailability = "set_availability"
+	BulkActionSetCategory     = "set_category"
+	BulkActionDelete          = "delete"
+)
+
+// Statuses of a product in a bulk operation result
+const (
+	BulkStatusUpdated = "updated"
+	BulkStatusDeleted = "deleted"
+	BulkStatusFailed  = "failed"
+)
+
+// BulkProductRequestDTO represents a batch of operations applied to products in one request
+type BulkProductRequestDTO struct {
+	Operations []BulkOperationDTO `json:"operations"`
+	DryRun     bool               `json:"dry_run"`
+}
+
+// BulkOperationDTO represents one action applied to the products selected by IDs or by Filter.
+// Value holds the new price, discount, availability or category and is unused for delete.
+type BulkOperationDTO struct {
+	Action string         `json:"action"`
+	Value  interface{}    `json:"value,omitempty"`
+	IDs    []string       `json:"ids,omitempty"`
+	Filter *BulkFilterDTO `json:"filter,omitempty"`
+}
+
+// BulkFilterDTO selects products for a bulk operation with the list endpoint filters
+type BulkFilterDTO struct {
+	Category     string   `json:"category,omitempty"`
+	SearchTerm   string   `json:"q,omitempty"`
+	BrandID      int      `json:"brand_id,omitempty"`
+	SellerID     int      `json:"seller_id,omitempty"`
+	MinPrice     *float64 `json:"min_price,omitempty"`
+	MaxPrice     *float64 `json:"max_price,omitempty"`
+	MinDiscount  *float64 `json:"min_discount,omitempty"`
+	Availability *bool    `json:"availability,omitempty"`
+}
+
+// ToProductFilter converts the bulk filter to a ProductFilter
+func (f *BulkFilterDTO) ToProductFilter() ProductFilter {
+	return ProductFilter{
+		Category:     f.Category,
+		SearchTerm:   f.SearchTerm,
+		BrandID:      f.BrandID,
+		SellerID:     f.SellerID,
+		MinPrice:     f.MinPrice,
+		MaxPrice:     f.MaxPrice,
+		MinDiscount:  f.MinDiscount,
+		Availability: f.Availability,
+	}
+}
+
+// IsEmpty reports whether the filter has no criteria and would select every product
+func (f *BulkFilterDTO) IsEmpty() bool {
+	return f.Category == "" && f.SearchTerm == "" && f.BrandID == 0 && f.SellerID == 0 &&
+		f.MinPrice == nil && f.MaxPrice == nil && f.MinDiscount == nil && f.Availability == nil
+}
+
+// BulkItemResultDTO represents the outcome of a bulk request for one product
+type BulkItemResultDTO struct {
+	ID        string   `json:"id"`
+	ProductID int      `json:"product_id,omitempty"`
+	Status    string   `json:"status"`
+	Actions   []string `json:"actions,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// BulkProductResponseDTO represents the per-product results of a bulk request
+type BulkProductResponseDTO struct {
+	DryRun    bool                `json:"dry_run"`
+	Total     int                 `json:"total"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []BulkItemResultDTO `json:"results"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+// Catalog file formats of the import and export endpoints. XML is export only.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXML    = "xml"
+)
+
+// Statuses of a row in a product import report
+const (
+	ImportStatusCreated = "created"
+	ImportStatusUpdated = "updated"
+	ImportStatusFailed  = "failed"
+)
+
+// ProductCSVColumns are the CSV header columns of a product catalog file.
+// item_dimensions holds a JSON object.
+var ProductCSVColumns = []string{
+	"product_id",
+	"product_name",
+	"description",
+	"brand_id",
+	"brand_name",
+	"seller_id",
+	"category",
+	"item_dimensions",
+	"price",
+	"discount",
+	"availability",
+}
+
+// ProductImportRowDTO represents one product line of an import file. A product_id of 0
+// creates a new product with the next sequential id.
+type ProductImportRowDTO struct {
+	ProductID int `json:"product_id"`
+	ProductRequestDTO
+}
+
+// ProductImportRowResult represents the outcome of importing one line
+type ProductImportRowResult struct {
+	Line      int         `json:"line"`
+	ProductID int         `json:"product_id,omitempty"`
+	Status    string      `json:"status"`
+	Error     string      `json:"error,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// ProductImportReport represents the per-row report of a product import
+type ProductImportReport struct {
+	DryRun  bool                     `json:"dry_run"`
+	Total   int                      `json:"total"`
+	Created int                      `json:"created"`
+	Updated int                      `json:"updated"`
+	Failed  int                      `json:"failed"`
+	Rows    []ProductImportRowResult `json:"rows"`
+}
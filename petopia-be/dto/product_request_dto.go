@@ -18,9 +18,19 @@ type ProductRequestDTO struct {
 type ProductFilter struct {
 	Category     string
 	SearchTerm   string
+	BrandID      int
+	SellerID     int
+	MinPrice     *float64
+	MaxPrice     *float64
+	MinDiscount  *float64
 	Page         int64
 	Limit        int64
+	UseCursor    bool
+	Cursor       string
 	SortBy       string
 	SortOrder    string
 	Availability *bool
+
+	// IncludeDeleted also returns soft-deleted products; only admins may set it
+	IncludeDeleted bool
 }
@@ -16,15 +16,30 @@ type ProductResponseDTO struct {
 	Price          float64                `json:"price"`
 	Discount       float64                `json:"discount"`
 	Availability   bool                   `json:"availability"`
+	Rating         RatingSummaryDTO       `json:"rating"`
+	Score          float64                `json:"score,omitempty"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
+	IsDeleted      bool                   `json:"is_deleted,omitempty"`
+	DeletedAt      *time.Time             `json:"deleted_at,omitempty"`
+	DeletedBy      int                    `json:"deleted_by,omitempty"`
 }
 
-// PaginatedResponse represents a paginated response with metadata
+// RatingSummaryDTO represents the review statistics embedded in a product response
+type RatingSummaryDTO struct {
+	AverageRating float64       `json:"average_rating"`
+	ReviewCount   int64         `json:"review_count"`
+	Histogram     map[int]int64 `json:"histogram"`
+}
+
+// PaginatedResponse represents a paginated response with metadata.
+// Page mode fills Page, cursor mode fills NextCursor and PrevCursor; both fill TotalPages.
 type PaginatedResponse struct {
 	Items      interface{} `json:"items"`
 	Total      int64       `json:"total"`
-	Page       int64       `json:"page"`
+	Page       int64       `json:"page,omitempty"`
 	Limit      int64       `json:"limit"`
 	TotalPages int64       `json:"total_pages"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	PrevCursor string      `json:"prev_cursor,omitempty"`
 }
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	mongomodels "petopia-be/models/mongo"
+)
+
+// MapReviewToDTO converts a CustomerReview model to a ReviewResponseDTO
+func MapReviewToDTO(review *mongomodels.CustomerReview) *ReviewResponseDTO {
+	if review == nil {
+		return nil
+	}
+
+	return &ReviewResponseDTO{
+		ID:               review.ID.Hex(),
+		ProductID:        review.ProductID,
+		CustomerID:       review.CustomerID,
+		CustomerName:     review.CustomerName,
+		Rating:           review.Rating,
+		Title:            review.Title,
+		Comment:          review.Comment,
+		Images:           review.Images,
+		VerifiedPurchase: review.VerifiedPurchase,
+		HelpfulVotes:     review.HelpfulVotes,
+		Filters:          review.Filters,
+		PetInfo:          review.PetInfo,
+		CreatedAt:        review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt,
+	}
+}
+
+// MapReviewsToDTOs converts a slice of CustomerReview pointers to a slice of ReviewResponseDTOs
+func MapReviewsToDTOs(reviews []*mongomodels.CustomerReview) []ReviewResponseDTO {
+	reviewDTOs := make([]ReviewResponseDTO, 0, len(reviews))
+	for _, review := range reviews {
+		reviewDTOs = append(reviewDTOs, *MapReviewToDTO(review))
+	}
+	return reviewDTOs
+}
+
+// MapDTOToReview converts a ReviewRequestDTO to a CustomerReview model for the given product
+func MapDTOToReview(dto *ReviewRequestDTO, productID int) *mongomodels.CustomerReview {
+	if dto == nil {
+		return nil
+	}
+
+	return &mongomodels.CustomerReview{
+		ProductID:        productID,
+		CustomerID:       dto.CustomerID,
+		CustomerName:     dto.CustomerName,
+		Rating:           dto.Rating,
+		Title:            dto.Title,
+		Comment:          dto.Comment,
+		Images:           dto.Images,
+		Filters:          dto.Filters,
+		PetInfo:          dto.PetInfo,
+	}
+}
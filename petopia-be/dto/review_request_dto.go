@@ -0,0 +1,28 @@
+package dto
+
+import mongomodels "petopia-be/models/mongo"
+
+// ReviewRequestDTO represents the data transfer object for a review request.
+// Whether the review is a verified purchase is derived from the customer's orders.
+type ReviewRequestDTO struct {
+	CustomerID       int                 `json:"customer_id"`
+	CustomerName     string              `json:"customer_name"`
+	Rating           float64             `json:"rating" binding:"required"`
+	Title            string              `json:"title"`
+	Comment          string              `json:"comment"`
+	Images           []string            `json:"images"`
+	Filters          map[string]string   `json:"filters"`
+	PetInfo          mongomodels.PetInfo `json:"pet_info"`
+}
+
+// ReviewFilter represents the filtering criteria for product reviews
+type ReviewFilter struct {
+	MinRating        *float64
+	VerifiedPurchase *bool
+	PetType          string
+	PetBreed         string
+	PetSize          string
+	SortBy           string
+	Page             int64
+	Limit            int64
+}
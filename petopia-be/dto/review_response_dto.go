@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	mongomodels "petopia-be/models/mongo"
+)
+
+// ReviewResponseDTO represents the data transfer object for a review response
+type ReviewResponseDTO struct {
+	ID               string              `json:"id"`
+	ProductID        int                 `json:"product_id"`
+	CustomerID       int                 `json:"customer_id"`
+	CustomerName     string              `json:"customer_name"`
+	Rating           float64             `json:"rating"`
+	Title            string              `json:"title"`
+	Comment          string              `json:"comment"`
+	Images           []string            `json:"images"`
+	VerifiedPurchase bool                `json:"verified_purchase"`
+	HelpfulVotes     int                 `json:"helpful_votes"`
+	Filters          map[string]string   `json:"filters"`
+	PetInfo          mongomodels.PetInfo `json:"pet_info"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+// FacetedSearchResponse represents a page of search hits together with facet counts of all matches
+type FacetedSearchResponse struct {
+	PaginatedResponse
+	Facets SearchFacetsDTO `json:"facets"`
+}
+
+// SearchFacetsDTO represents the facet counts shown in the storefront filter sidebar
+type SearchFacetsDTO struct {
+	Categories   []FacetValueDTO  `json:"categories"`
+	Brands       []FacetValueDTO  `json:"brands"`
+	Availability []FacetValueDTO  `json:"availability"`
+	PriceBuckets []PriceBucketDTO `json:"price_buckets"`
+}
+
+// FacetValueDTO represents the number of matching products sharing a value
+type FacetValueDTO struct {
+	Value interface{} `json:"value"`
+	Count int64       `json:"count"`
+}
+
+// PriceBucketDTO represents the number of matching products in a price range [min, max)
+type PriceBucketDTO struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max"`
+	Count int64    `json:"count"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	pgmodels "petopia-be/models/postgres"
+)
+
+// MapSellerToDTO converts a Seller model to a SellerResponseDTO
+func MapSellerToDTO(seller *pgmodels.Seller) *SellerResponseDTO {
+	if seller == nil {
+		return nil
+	}
+
+	return &SellerResponseDTO{
+		ID:            seller.ID,
+		SellerName:    seller.SellerName,
+		SellerInfo:    seller.SellerInfo,
+		SellerAddress: seller.SellerAddress,
+		CreatedAt:     seller.CreatedAt,
+		CreatedBy:     seller.CreatedBy,
+		UpdatedAt:     seller.UpdatedAt,
+		UpdatedBy:     seller.UpdatedBy,
+	}
+}
+
+// MapSellersToDTOs converts a slice of Seller models to a slice of SellerResponseDTOs
+func MapSellersToDTOs(sellers []pgmodels.Seller) []SellerResponseDTO {
+	sellerDTOs := make([]SellerResponseDTO, len(sellers))
+	for i, seller := range sellers {
+		sellerDTOs[i] = *MapSellerToDTO(&seller)
+	}
+	return sellerDTOs
+}
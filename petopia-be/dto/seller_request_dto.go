@@ -0,0 +1,8 @@
+package dto
+
+// SellerRequestDTO represents the data transfer object for creating or updating a seller
+type SellerRequestDTO struct {
+	SellerName    string `json:"seller_name" binding:"required"`
+	SellerInfo    string `json:"seller_info"`
+	SellerAddress string `json:"seller_address" binding:"required"`
+}
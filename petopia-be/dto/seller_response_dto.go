@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// SellerResponseDTO represents the data transfer object for a seller response
+type SellerResponseDTO struct {
+	ID            int        `json:"id"`
+	SellerName    string     `json:"seller_name"`
+	SellerInfo    string     `json:"seller_info,omitempty"`
+	SellerAddress string     `json:"seller_address"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CreatedBy     *int       `json:"created_by,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy     *int       `json:"updated_by,omitempty"`
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"sort"
+
+	pgmodels "petopia-be/models/postgres"
+)
+
+// ShipmentStatusCreated is the status of a shipment without tracking events
+const ShipmentStatusCreated = "created"
+
+// MapShipmentToDTO converts a Shipment model to a ShipmentResponseDTO
+func MapShipmentToDTO(shipment *pgmodels.Shipment) *ShipmentResponseDTO {
+	if shipment == nil {
+		return nil
+	}
+
+	return &ShipmentResponseDTO{
+		ID:               shipment.ID,
+		UserID:           shipment.UserID,
+		ProductID:        shipment.ProductID,
+		OrderedProductID: shipment.OrderedProductID,
+		Address:          shipment.Address,
+		DeliveryPartner:  shipment.AssignedDeliveryPartner,
+		Status:           MapShipmentTrackingToDTO(shipment).Status,
+		Delivered:        shipment.Delivered(),
+		CreatedAt:        shipment.CreatedAt,
+		UpdatedAt:        shipment.UpdatedAt,
+	}
+}
+
+// MapShipmentsToDTOs converts a slice of Shipment models to a slice of ShipmentResponseDTOs
+func MapShipmentsToDTOs(shipments []pgmodels.Shipment) []ShipmentResponseDTO {
+	shipmentDTOs := make([]ShipmentResponseDTO, len(shipments))
+	for i, shipment := range shipments {
+		shipmentDTOs[i] = *MapShipmentToDTO(&shipment)
+	}
+	return shipmentDTOs
+}
+
+// MapShipmentTrackingToDTO converts a shipment's tracking events to a timeline ordered by when
+// they occurred; events recorded late still appear in place
+func MapShipmentTrackingToDTO(shipment *pgmodels.Shipment) *ShipmentTrackingResponseDTO {
+	events := make([]TrackingEventDTO, len(shipment.LocationTracking))
+	for i, event := range shipment.LocationTracking {
+		events[i] = TrackingEventDTO{
+			Status:     event.Status,
+			Location:   event.Location,
+			Note:       event.Note,
+			OccurredAt: event.OccurredAt,
+			RecordedAt: event.RecordedAt,
+			RecordedBy: event.RecordedBy,
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+
+	tracking := &ShipmentTrackingResponseDTO{
+		ShipmentID: shipment.ID,
+		Status:     ShipmentStatusCreated,
+		Delivered:  shipment.Delivered(),
+		Events:     events,
+	}
+	if len(events) > 0 {
+		tracking.Status = events[len(events)-1].Status
+	}
+	if tracking.Delivered {
+		tracking.Status = pgmodels.TrackingStatusDelivered
+	}
+	return tracking
+}
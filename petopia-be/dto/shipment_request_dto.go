@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// ShipmentRequestDTO represents the data transfer object for shipping products of an order.
+// Without ItemIDs every ordered product that is neither delivered nor shipped is shipped.
+type ShipmentRequestDTO struct {
+	ItemIDs         []int  `json:"item_ids"`
+	DeliveryPartner string `json:"delivery_partner"`
+}
+
+// DeliveryPartnerRequestDTO represents the data transfer object for assigning a delivery partner
+type DeliveryPartnerRequestDTO struct {
+	DeliveryPartner string `json:"delivery_partner" binding:"required"`
+}
+
+// TrackingEventRequestDTO represents the data transfer object for a shipment tracking event.
+// OccurredAt defaults to the time the event is recorded.
+type TrackingEventRequestDTO struct {
+	Status     string     `json:"status" binding:"required"`
+	Location   string     `json:"location" binding:"required"`
+	Note       string     `json:"note"`
+	OccurredAt *time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// ShipmentResponseDTO represents the data transfer object for a shipment response.
+// Status is the status of the latest tracking event, or "created" before the first one.
+type ShipmentResponseDTO struct {
+	ID               int        `json:"id"`
+	UserID           int        `json:"user_id"`
+	ProductID        int        `json:"product_id"`
+	OrderedProductID *int       `json:"ordered_product_id,omitempty"`
+	Address          string     `json:"address"`
+	DeliveryPartner  *string    `json:"delivery_partner,omitempty"`
+	Status           string     `json:"status"`
+	Delivered        bool       `json:"delivered"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// ShipmentTrackingResponseDTO represents a shipment's tracking timeline, oldest event first
+type ShipmentTrackingResponseDTO struct {
+	ShipmentID int                `json:"shipment_id"`
+	Status     string             `json:"status"`
+	Delivered  bool               `json:"delivered"`
+	Events     []TrackingEventDTO `json:"events"`
+}
+
+// TrackingEventDTO represents one shipment tracking event
+type TrackingEventDTO struct {
+	Status     string    `json:"status"`
+	Location   string    `json:"location"`
+	Note       string    `json:"note,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+	RecordedAt time.Time `json:"recorded_at"`
+	RecordedBy *int      `json:"recorded_by,omitempty"`
+}
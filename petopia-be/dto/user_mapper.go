@@ -0,0 +1,49 @@
+package dto
+
+import (
+	pgmodels "petopia-be/models/postgres"
+)
+
+// MapUserToDTO converts a UserAccount model and its addresses to a UserResponseDTO
+func MapUserToDTO(user *pgmodels.UserAccount, addresses []pgmodels.UserAddress) *UserResponseDTO {
+	if user == nil {
+		return nil
+	}
+
+	return &UserResponseDTO{
+		ID:        user.ID,
+		Name:      user.Name,
+		Addresses: MapAddressesToDTOs(addresses),
+		CreatedAt: user.CreatedAt,
+		CreatedBy: user.CreatedBy,
+		UpdatedAt: user.UpdatedAt,
+		UpdatedBy: user.UpdatedBy,
+	}
+}
+
+// MapAddressToDTO converts a UserAddress model to an AddressResponseDTO
+func MapAddressToDTO(address *pgmodels.UserAddress) *AddressResponseDTO {
+	if address == nil {
+		return nil
+	}
+
+	return &AddressResponseDTO{
+		ID:        address.ID,
+		UserID:    address.UserID,
+		Address:   address.Address,
+		Pincode:   address.Pincode,
+		CreatedAt: address.CreatedAt,
+		CreatedBy: address.CreatedBy,
+		UpdatedAt: address.UpdatedAt,
+		UpdatedBy: address.UpdatedBy,
+	}
+}
+
+// MapAddressesToDTOs converts a slice of UserAddress models to a slice of AddressResponseDTOs
+func MapAddressesToDTOs(addresses []pgmodels.UserAddress) []AddressResponseDTO {
+	addressDTOs := make([]AddressResponseDTO, len(addresses))
+	for i, address := range addresses {
+		addressDTOs[i] = *MapAddressToDTO(&address)
+	}
+	return addressDTOs
+}
@@ -0,0 +1,12 @@
+package dto
+
+// UserRequestDTO represents the data transfer object for creating or updating a user account
+type UserRequestDTO struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// AddressRequestDTO represents the data transfer object for creating or updating a user address
+type AddressRequestDTO struct {
+	Address string `json:"address" binding:"required"`
+	Pincode string `json:"pincode" binding:"required"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// UserResponseDTO represents the data transfer object for a user account response
+type UserResponseDTO struct {
+	ID        int                  `json:"id"`
+	Name      string               `json:"name"`
+	Addresses []AddressResponseDTO `json:"addresses"`
+	CreatedAt time.Time            `json:"created_at"`
+	CreatedBy *int                 `json:"created_by,omitempty"`
+	UpdatedAt *time.Time           `json:"updated_at,omitempty"`
+	UpdatedBy *int                 `json:"updated_by,omitempty"`
+}
+
+// AddressResponseDTO represents the data transfer object for a user address response
+type AddressResponseDTO struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"user_id"`
+	Address   string     `json:"address"`
+	Pincode   string     `json:"pincode"`
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy *int       `json:"created_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy *int       `json:"updated_by,omitempty"`
+}
@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"petopia-be/service"
+	"time"
+)
+
+// ProductPurgeInterval is how often soft-deleted products are checked for purging
+const ProductPurgeInterval = 24 * time.Hour
+
+// RunProductPurge permanently removes products soft deleted longer ago than the retention
+// period, once at startup and then on every interval tick until ctx is cancelled
+func RunProductPurge(ctx context.Context, productService service.ProductService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := productService.PurgeDeletedProducts(ctx, retention)
+		if err != nil {
+			log.Printf("Error purging deleted products: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d deleted products older than %s", purged, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"os"
 	"petopia-be/config"
 	"petopia-be/db"
+	"petopia-be/jobs"
+	"petopia-be/migrations"
+	"petopia-be/repository"
 	"petopia-be/seed"
 	"petopia-be/server"
+	"petopia-be/service"
+	"time"
 
 	_ "petopia-be/docs"
 
@@ -56,6 +64,24 @@ func main() {
 		log.Fatalf("Could not connect to PostgreSQL: %v", err)
 	}
 
+	// Apply pending SQL migrations, or run a migrate command and exit
+	migrator, err := db.NewMigrator(database, migrations.Files)
+	if err != nil {
+		log.Fatalf("Could not load migrations: %v", err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(context.Background(), migrator, os.Args[2:]); err != nil {
+			log.Fatalf("Migrate failed: %v", err)
+		}
+		return
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		log.Fatalf("Error applying migrations: %v", err)
+	}
+	if err := requireCardVaultKey(context.Background(), migrator, cfg.CardVaultKey); err != nil {
+		log.Fatalf("Card vault is not configured: %v", err)
+	}
+
 	// Initialize MongoDB with retry mechanism
 	_, err = db.ConnectMongoDBWithRetry(3)
 	if err != nil {
@@ -75,6 +101,19 @@ func main() {
 		}
 	}()
 
+	// Review or apply the product_id repair the MongoDB migrations ask for, then exit
+	if len(os.Args) > 1 && os.Args[1] == "repair-product-ids" {
+		if err := runRepairProductIDsCommand(context.Background(), os.Args[2:]); err != nil {
+			log.Fatalf("Repair failed: %v", err)
+		}
+		return
+	}
+
+	// Apply pending MongoDB index and schema validator migrations
+	if err := db.MigrateMongo(); err != nil {
+		log.Fatalf("Error applying MongoDB migrations: %v", err)
+	}
+
 	// Seed the database
 	if err := seed.SeedDatabase(database); err != nil {
 		log.Fatalf("Error seeding PostgreSQL database: %v", err)
@@ -85,6 +124,51 @@ func main() {
 		log.Fatalf("Error seeding MongoDB database: %v", err)
 	}
 
+	// Keep the product_id sequence ahead of seeded and imported products
+	if err := repository.NewMongoProductRepository().SyncProductIDCounter(context.Background()); err != nil {
+		log.Fatalf("Error syncing product_id counter: %v", err)
+	}
+
+	// Build the services shared by startup jobs and the HTTP routes
+	services, err := service.NewServiceContainer(cfg, database)
+	if err != nil {
+		log.Fatalf("Could not create services: %v", err)
+	}
+
+	// Seal card numbers saved in plain text before the card vault existed
+	if cfg.CardVaultKey != "" {
+		if sealed, err := services.CardService.SealLegacyCards(context.Background()); err != nil {
+			log.Printf("Error sealing legacy payment cards: %v", err)
+		} else if sealed > 0 {
+			log.Printf("Sealed %d legacy payment cards", sealed)
+		}
+	}
+
+	// Purge soft-deleted products once they are past the retention period
+	if cfg.ProductRetentionDays > 0 {
+		retention := time.Duration(cfg.ProductRetentionDays) * 24 * time.Hour
+		go jobs.RunProductPurge(context.Background(), services.ProductService, retention, jobs.ProductPurgeInterval)
+	}
+
 	// Start server
-	log.Fatal(server.StartV2(cfg, database))
+	log.Fatal(server.StartV2(cfg, services))
+}
+
+// requireCardVaultKey fails when the card vault migration is applied but no vault key is
+// configured, as stored cards could then neither be added nor sealed
+func requireCardVaultKey(ctx context.Context, migrator *db.Migrator, key string) error {
+	if key != "" {
+		return nil
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if status.Version == migrations.CardVaultVersion && status.Applied {
+			return errors.New("CARD_VAULT_KEY is required once migration 0007 is applied")
+		}
+	}
+	return nil
 }
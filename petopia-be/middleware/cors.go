@@ -9,8 +9,9 @@ import (
 func CORS(allowedOrigin string) func(http.Handler) http.Handler {
 	c := cors.New(cors.Options{
 		AllowedOrigins: []string{allowedOrigin},
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders: []string{"*"},
+		ExposedHeaders: []string{"ETag", "X-Request-ID", "Idempotent-Replayed"},
 	})
 
 	return c.Handler
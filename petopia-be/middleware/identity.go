@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+)
+
+// Headers identifying the caller
+const (
+	UserIDHeader   = "X-User-ID"
+	AdminKeyHeader = "X-Admin-Key"
+)
+
+type userIDKey struct{}
+
+type adminKey struct{}
+
+// Identity stores the calling user ID from X-User-ID in the request context and marks the
+// request as admin when X-Admin-Key matches the configured key. An empty key disables admin access.
+func Identity(adminAPIKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if userID, err := strconv.Atoi(r.Header.Get(UserIDHeader)); err == nil && userID > 0 {
+				ctx = context.WithValue(ctx, userIDKey{}, userID)
+			}
+
+			providedKey := r.Header.Get(AdminKeyHeader)
+			if adminAPIKey != "" && subtle.ConstantTimeCompare([]byte(providedKey), []byte(adminAPIKey)) == 1 {
+				ctx = context.WithValue(ctx, adminKey{}, true)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetUserID returns the calling user ID stored in the context, or 0 when unknown
+func GetUserID(ctx context.Context) int {
+	userID, _ := ctx.Value(userIDKey{}).(int)
+	return userID
+}
+
+// IsAdmin reports whether the request was authenticated with the admin key
+func IsAdmin(ctx context.Context) bool {
+	isAdmin, _ := ctx.Value(adminKey{}).(bool)
+	return isAdmin
+}
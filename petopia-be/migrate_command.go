@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"petopia-be/db"
+	"strconv"
+	"text/tabwriter"
+)
+
+// migrateUsage describes the migrate command
+const migrateUsage = "usage: migrate up | down [steps] | to <version> | status"
+
+// runMigrateCommand runs a migrate subcommand such as "migrate down 2"
+func runMigrateCommand(ctx context.Context, migrator *db.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			parsed, err := strconv.Atoi(args[1])
+			if err != nil || parsed <= 0 {
+				return fmt.Errorf("steps must be a positive integer, got %q", args[1])
+			}
+			steps = parsed
+		}
+		return migrator.Down(ctx, steps)
+	case "to":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("version must be a non-negative integer, got %q", args[1])
+		}
+		return migrator.To(ctx, version)
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// printMigrationStatus writes a table of every migration and whether it is applied
+func printMigrationStatus(ctx context.Context, migrator *db.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state := "pending"
+		switch {
+		case status.Missing:
+			state = "applied, file missing"
+		case status.Modified:
+			state = "applied, modified"
+		case status.Applied:
+			state = "applied"
+		}
+
+		appliedAt := ""
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+		fmt.Fprintf(writer, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return writer.Flush()
+}
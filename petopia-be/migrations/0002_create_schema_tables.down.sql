@@ -4,7 +4,16 @@ DROP TABLE IF EXISTS products_ordered;
 DROP TABLE IF EXISTS orders;
 DROP TABLE IF EXISTS cart;
 DROP TABLE IF EXISTS inventory_information;
-DROP TABLE IF EXISTS products;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS is_deleted,
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS created_at;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_price_check;
+ALTER TABLE products RENAME COLUMN product_name TO name;
 DROP TABLE IF EXISTS user_card_details;
 DROP TABLE IF EXISTS user_addresses;
 DROP TABLE IF EXISTS seller_info;
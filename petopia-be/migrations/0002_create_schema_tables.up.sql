@@ -55,20 +55,17 @@ CREATE TABLE seller_info (
     is_deleted BOOLEAN NOT NULL DEFAULT FALSE
 );
 
--- Create Products Table
-CREATE TABLE products (
-    id SERIAL PRIMARY KEY,
-    product_name VARCHAR(255) NOT NULL,
-    description TEXT,
-    price NUMERIC(10, 2) NOT NULL CHECK (price >= 0),
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
-    created_by INTEGER REFERENCES user_accounts(id),
-    updated_at TIMESTAMP WITH TIME ZONE,
-    updated_by INTEGER REFERENCES user_accounts(id),
-    deleted_at TIMESTAMP WITH TIME ZONE,
-    deleted_by INTEGER REFERENCES user_accounts(id),
-    is_deleted BOOLEAN NOT NULL DEFAULT FALSE
-);
+-- Extend the Products Table created by 0001
+ALTER TABLE products RENAME COLUMN name TO product_name;
+ALTER TABLE products ADD CONSTRAINT products_price_check CHECK (price >= 0);
+ALTER TABLE products
+    ADD COLUMN created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN created_by INTEGER REFERENCES user_accounts(id),
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN updated_by INTEGER REFERENCES user_accounts(id),
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN deleted_by INTEGER REFERENCES user_accounts(id),
+    ADD COLUMN is_deleted BOOLEAN NOT NULL DEFAULT FALSE;
 
 -- Create Inventory Information Table
 CREATE TABLE inventory_information (
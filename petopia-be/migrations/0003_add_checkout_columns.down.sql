@@ -0,0 +1,3 @@
+ALTER TABLE products_ordered DROP COLUMN IF EXISTS discount;
+DROP INDEX IF EXISTS orders_user_id_idempotency_key_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS idempotency_key;
@@ -0,0 +1,6 @@
+-- Record the Idempotency-Key a checkout was placed with so retries return the same order
+ALTER TABLE orders ADD COLUMN idempotency_key VARCHAR(255);
+CREATE UNIQUE INDEX orders_user_id_idempotency_key_idx ON orders (user_id, idempotency_key);
+
+-- Snapshot the discount percentage an ordered product was sold at
+ALTER TABLE products_ordered ADD COLUMN discount NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (discount >= 0 AND discount <= 100);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS inventory_information_product_seller_location_idx;
+ALTER TABLE inventory_information DROP CONSTRAINT IF EXISTS inventory_information_reserved_check;
+ALTER TABLE inventory_information DROP COLUMN IF EXISTS reserved;
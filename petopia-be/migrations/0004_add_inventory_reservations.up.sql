@@ -0,0 +1,8 @@
+-- Track stock held for customers separately from stock on hand
+ALTER TABLE inventory_information ADD COLUMN reserved INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inventory_information ADD CONSTRAINT inventory_information_reserved_check CHECK (reserved >= 0 AND reserved <= quantity);
+
+-- A seller holds at most one stock row per product and location
+CREATE UNIQUE INDEX inventory_information_product_seller_location_idx
+    ON inventory_information (product_id, seller_id, inventory_location)
+    WHERE is_deleted = FALSE;
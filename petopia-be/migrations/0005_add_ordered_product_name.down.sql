@@ -0,0 +1 @@
+ALTER TABLE products_ordered DROP COLUMN IF EXISTS product_name;
@@ -0,0 +1,2 @@
+-- Snapshot the product name at checkout so order history survives catalog changes
+ALTER TABLE products_ordered ADD COLUMN product_name VARCHAR(255) NOT NULL DEFAULT '';
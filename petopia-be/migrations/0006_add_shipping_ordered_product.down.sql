@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS shipping_ordered_product_id_idx;
+ALTER TABLE shipping DROP COLUMN IF EXISTS ordered_product_id;
@@ -0,0 +1,3 @@
+-- Link each shipment to the ordered product it delivers; an ordered product has at most one live shipment
+ALTER TABLE shipping ADD COLUMN ordered_product_id INTEGER REFERENCES products_ordered(id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX shipping_ordered_product_id_idx ON shipping (ordered_product_id) WHERE is_deleted = FALSE;
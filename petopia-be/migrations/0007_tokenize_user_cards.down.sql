@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS user_card_details_user_fingerprint_idx;
+DROP INDEX IF EXISTS user_card_details_token_idx;
+
+-- Sealed cards cannot be restored to plain text without the vault key
+DELETE FROM user_card_details WHERE card_number IS NULL;
+
+ALTER TABLE user_card_details DROP COLUMN IF EXISTS fingerprint;
+ALTER TABLE user_card_details DROP COLUMN IF EXISTS key_id;
+ALTER TABLE user_card_details DROP COLUMN IF EXISTS encrypted_key;
+ALTER TABLE user_card_details DROP COLUMN IF EXISTS encrypted_number;
+ALTER TABLE user_card_details DROP COLUMN IF EXISTS expiry_year;
+ALTER TABLE user_card_details DROP COLUMN IF EXISTS expiry_month;
+ALTER TABLE user_card_details DROP COLUMN IF EXISTS brand;
+ALTER TABLE user_card_details DROP COLUMN IF EXISTS last4;
+ALTER TABLE user_card_details DROP COLUMN IF EXISTS token;
+ALTER TABLE user_card_details ALTER COLUMN card_number SET NOT NULL;
@@ -0,0 +1,17 @@
+-- Cards are stored sealed by the card vault; card_number only holds legacy plain text numbers
+-- until they are sealed at startup
+ALTER TABLE user_card_details ALTER COLUMN card_number DROP NOT NULL;
+ALTER TABLE user_card_details ADD COLUMN token VARCHAR(64);
+ALTER TABLE user_card_details ADD COLUMN last4 VARCHAR(4);
+ALTER TABLE user_card_details ADD COLUMN brand VARCHAR(20);
+ALTER TABLE user_card_details ADD COLUMN expiry_month SMALLINT CHECK (expiry_month BETWEEN 1 AND 12);
+ALTER TABLE user_card_details ADD COLUMN expiry_year SMALLINT;
+ALTER TABLE user_card_details ADD COLUMN encrypted_number BYTEA;
+ALTER TABLE user_card_details ADD COLUMN encrypted_key BYTEA;
+ALTER TABLE user_card_details ADD COLUMN key_id VARCHAR(32);
+ALTER TABLE user_card_details ADD COLUMN fingerprint VARCHAR(64);
+
+CREATE UNIQUE INDEX user_card_details_token_idx ON user_card_details (token);
+
+-- A user can save the same card only once
+CREATE UNIQUE INDEX user_card_details_user_fingerprint_idx ON user_card_details (user_id, fingerprint) WHERE is_deleted = FALSE;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS cart_user_id_product_id_idx;
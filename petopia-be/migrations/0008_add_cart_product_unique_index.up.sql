@@ -0,0 +1,23 @@
+-- Fold duplicate cart lines into the oldest line of each product, as adding the product again would
+UPDATE cart
+SET quantity = LEAST(duplicates.total, 99), updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT MIN(id) AS id, SUM(quantity) AS total
+    FROM cart
+    WHERE is_deleted = FALSE
+    GROUP BY user_id, product_id
+    HAVING COUNT(*) > 1
+) duplicates
+WHERE cart.id = duplicates.id;
+
+UPDATE cart
+SET is_deleted = TRUE, deleted_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT id, MIN(id) OVER (PARTITION BY user_id, product_id) AS kept_id
+    FROM cart
+    WHERE is_deleted = FALSE
+) duplicates
+WHERE cart.id = duplicates.id AND duplicates.id <> duplicates.kept_id;
+
+-- A user has at most one live cart line per product
+CREATE UNIQUE INDEX cart_user_id_product_id_idx ON cart (user_id, product_id) WHERE is_deleted = FALSE;
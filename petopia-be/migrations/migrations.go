@@ -0,0 +1,14 @@
+// Package migrations embeds the versioned SQL migrations applied by db.Migrator. Each version
+// is a pair of files named NNNN_description.up.sql and NNNN_description.down.sql.
+package migrations
+
+import "embed"
+
+// CardVaultVersion is the migration from which stored card numbers are sealed by the card
+// vault, so CARD_VAULT_KEY is required once it is applied
+const CardVaultVersion = 7
+
+// Files holds every migration file
+//
+//go:embed *.sql
+var Files embed.FS
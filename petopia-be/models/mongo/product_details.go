@@ -22,4 +22,7 @@ type ProductDetails struct {
 	Availability   bool                   `bson:"availability" json:"availability"`
 	CreatedAt      time.Time              `bson:"created_at" json:"created_at"`
 	UpdatedAt      time.Time              `bson:"updated_at" json:"updated_at"`
+	IsDeleted      bool                   `bson:"is_deleted" json:"is_deleted"`
+	DeletedAt      *time.Time             `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy      int                    `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
 }
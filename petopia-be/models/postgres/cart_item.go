@@ -0,0 +1,16 @@
+package postgres
+
+// CartItem represents a row of the cart table. ProductID is the product_id shared by the
+// Postgres products table and the Mongo product catalog.
+type CartItem struct {
+	ID        int `gorm:"column:id;primaryKey" json:"id"`
+	UserID    int `gorm:"column:user_id;not null" json:"user_id"`
+	ProductID int `gorm:"column:product_id;not null" json:"product_id"`
+	Quantity  int `gorm:"column:quantity;not null" json:"quantity"`
+	Audit
+}
+
+// TableName returns the table backing CartItem
+func (CartItem) TableName() string {
+	return "cart"
+}
@@ -0,0 +1,24 @@
+package postgres
+
+// InventoryItem represents a row of the inventory_information table: the stock of one
+// product held by a seller at a location. Reserved units are part of Quantity but are
+// held for customers and cannot be sold.
+type InventoryItem struct {
+	ID                int    `gorm:"column:id;primaryKey" json:"id"`
+	ProductID         int    `gorm:"column:product_id;not null" json:"product_id"`
+	SellerID          int    `gorm:"column:seller_id;not null" json:"seller_id"`
+	InventoryLocation string `gorm:"column:inventory_location;not null" json:"inventory_location"`
+	Quantity          int    `gorm:"column:quantity;not null" json:"quantity"`
+	Reserved          int    `gorm:"column:reserved;not null;default:0" json:"reserved"`
+	Audit
+}
+
+// Available returns the units that can still be sold
+func (i InventoryItem) Available() int {
+	return i.Quantity - i.Reserved
+}
+
+// TableName returns the table backing InventoryItem
+func (InventoryItem) TableName() string {
+	return "inventory_information"
+}
@@ -0,0 +1,23 @@
+package postgres
+
+import "time"
+
+// Audit holds the audit columns shared by every Postgres table. Rows are soft deleted
+// through IsDeleted, DeletedAt and DeletedBy; the *By columns reference user_accounts.
+type Audit struct {
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	CreatedBy *int       `gorm:"column:created_by" json:"created_by,omitempty"`
+	UpdatedAt *time.Time `gorm:"column:updated_at;autoUpdateTime:false" json:"updated_at,omitempty"`
+	UpdatedBy *int       `gorm:"column:updated_by" json:"updated_by,omitempty"`
+	DeletedAt *time.Time `gorm:"column:deleted_at" json:"deleted_at,omitempty"`
+	DeletedBy *int       `gorm:"column:deleted_by" json:"deleted_by,omitempty"`
+	IsDeleted bool       `gorm:"column:is_deleted;not null;default:false" json:"is_deleted"`
+}
+
+// ActorRef returns the audit column value for an acting user, or nil when the actor is unknown
+func ActorRef(actorID int) *int {
+	if actorID <= 0 {
+		return nil
+	}
+	return &actorID
+}
@@ -0,0 +1,51 @@
+package postgres
+
+// Delivery statuses of an ordered product
+const (
+	DeliveryStatusNotDelivered = "Not Delivered"
+	DeliveryStatusDelivered    = "Delivered"
+)
+
+// Return statuses of an ordered product
+const (
+	ReturnStatusNone      = "None"
+	ReturnStatusRequested = "Return Requested"
+	ReturnStatusReturned  = "Returned"
+)
+
+// Order represents a row of the orders table. Address is a snapshot of the delivery address
+// at checkout and IdempotencyKey the key the order was placed with, if any.
+type Order struct {
+	ID             int              `gorm:"column:id;primaryKey" json:"id"`
+	UserID         int              `gorm:"column:user_id;not null" json:"user_id"`
+	Address        string           `gorm:"column:address;not null" json:"address"`
+	TotalPrice     float64          `gorm:"column:total_price;not null" json:"total_price"`
+	IdempotencyKey *string          `gorm:"column:idempotency_key" json:"-"`
+	Items          []OrderedProduct `gorm:"foreignKey:OrderID" json:"items"`
+	Audit
+}
+
+// TableName returns the table backing Order
+func (Order) TableName() string {
+	return "orders"
+}
+
+// OrderedProduct represents a row of the products_ordered table. ProductName, Price and
+// Discount are snapshots of the catalog name, unit price and discount percentage at checkout.
+type OrderedProduct struct {
+	ID             int     `gorm:"column:id;primaryKey" json:"id"`
+	OrderID        int     `gorm:"column:order_id;not null" json:"order_id"`
+	ProductID      int     `gorm:"column:product_id;not null" json:"product_id"`
+	ProductName    string  `gorm:"column:product_name;not null" json:"product_name"`
+	Quantity       int     `gorm:"column:quantity;not null" json:"quantity"`
+	Price          float64 `gorm:"column:price;not null" json:"price"`
+	Discount       float64 `gorm:"column:discount;not null" json:"discount"`
+	DeliveryStatus string  `gorm:"column:delivery_status;not null" json:"delivery_status"`
+	ReturnStatus   string  `gorm:"column:return_status;not null" json:"return_status"`
+	Audit
+}
+
+// TableName returns the table backing OrderedProduct
+func (OrderedProduct) TableName() string {
+	return "products_ordered"
+}
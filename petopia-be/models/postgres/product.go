@@ -0,0 +1,16 @@
+package postgres
+
+// Product represents a row of the products table, the order catalog that cart, inventory
+// and ordered product rows reference. ID is the product_id of the Mongo catalog product.
+type Product struct {
+	ID          int     `gorm:"column:id;primaryKey" json:"id"`
+	ProductName string  `gorm:"column:product_name;not null" json:"product_name"`
+	Description string  `gorm:"column:description" json:"description"`
+	Price       float64 `gorm:"column:price;not null" json:"price"`
+	Audit
+}
+
+// TableName returns the table backing Product
+func (Product) TableName() string {
+	return "products"
+}
@@ -0,0 +1,16 @@
+package postgres
+
+// Seller represents a row of the seller_info table. Products in the Mongo catalog reference
+// a seller through ProductDetails.SellerID.
+type Seller struct {
+	ID            int    `gorm:"column:id;primaryKey" json:"id"`
+	SellerName    string `gorm:"column:seller_name;not null" json:"seller_name"`
+	SellerInfo    string `gorm:"column:seller_info" json:"seller_info"`
+	SellerAddress string `gorm:"column:seller_address;not null" json:"seller_address"`
+	Audit
+}
+
+// TableName returns the table backing Seller
+func (Seller) TableName() string {
+	return "seller_info"
+}
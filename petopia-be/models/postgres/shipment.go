@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Statuses of a shipment tracking event
+const (
+	TrackingStatusPickedUp       = "picked_up"
+	TrackingStatusInTransit      = "in_transit"
+	TrackingStatusOutForDelivery = "out_for_delivery"
+	TrackingStatusDeliveryFailed = "delivery_failed"
+	TrackingStatusDelivered      = "delivered"
+)
+
+// Shipment represents a row of the shipping table: the delivery of one ordered product.
+// OrderedProductID is nil only for rows written before shipments were linked to orders.
+type Shipment struct {
+	ID                      int            `gorm:"column:id;primaryKey" json:"id"`
+	UserID                  int            `gorm:"column:user_id;not null" json:"user_id"`
+	ProductID               int            `gorm:"column:product_id;not null" json:"product_id"`
+	OrderedProductID        *int           `gorm:"column:ordered_product_id" json:"ordered_product_id"`
+	Address                 string         `gorm:"column:address;not null" json:"address"`
+	LocationTracking        TrackingEvents `gorm:"column:location_tracking;type:jsonb" json:"location_tracking"`
+	AssignedDeliveryPartner *string        `gorm:"column:assigned_delivery_partner" json:"assigned_delivery_partner"`
+	Audit
+}
+
+// TableName returns the table backing Shipment
+func (Shipment) TableName() string {
+	return "shipping"
+}
+
+// Delivered reports whether a delivered event has been recorded for the shipment
+func (s *Shipment) Delivered() bool {
+	for _, event := range s.LocationTracking {
+		if event.Status == TrackingStatusDelivered {
+			return true
+		}
+	}
+	return false
+}
+
+// TrackingEvent is one entry of a shipment's location_tracking timeline. OccurredAt is when
+// the event happened and RecordedAt when it was appended.
+type TrackingEvent struct {
+	Status     string    `json:"status"`
+	Location   string    `json:"location"`
+	Note       string    `json:"note,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+	RecordedAt time.Time `json:"recorded_at"`
+	RecordedBy *int      `json:"recorded_by,omitempty"`
+}
+
+// TrackingEvents is the JSONB array stored in location_tracking
+type TrackingEvents []TrackingEvent
+
+// Value encodes the events as JSON for the database
+func (e TrackingEvents) Value() (driver.Value, error) {
+	if e == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+// Scan decodes the events from a JSONB column value
+func (e *TrackingEvents) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("unsupported location_tracking value")
+	}
+	return json.Unmarshal(raw, e)
+}
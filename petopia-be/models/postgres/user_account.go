@@ -0,0 +1,13 @@
+package postgres
+
+// UserAccount represents a row of the user_accounts table
+type UserAccount struct {
+	ID   int    `gorm:"column:id;primaryKey" json:"id"`
+	Name string `gorm:"column:name;not null" json:"name"`
+	Audit
+}
+
+// TableName returns the table backing UserAccount
+func (UserAccount) TableName() string {
+	return "user_accounts"
+}
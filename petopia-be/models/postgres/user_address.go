@@ -0,0 +1,15 @@
+package postgres
+
+// UserAddress represents a row of the user_addresses table
+type UserAddress struct {
+	ID      int    `gorm:"column:id;primaryKey" json:"id"`
+	UserID  int    `gorm:"column:user_id;not null" json:"user_id"`
+	Address string `gorm:"column:address;not null" json:"address"`
+	Pincode string `gorm:"column:pincode;not null" json:"pincode"`
+	Audit
+}
+
+// TableName returns the table backing UserAddress
+func (UserAddress) TableName() string {
+	return "user_addresses"
+}
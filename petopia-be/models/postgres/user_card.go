@@ -0,0 +1,26 @@
+package postgres
+
+// UserCard represents a row of the user_card_details table. The card number is only stored
+// sealed by the card vault; the sealed values and fingerprint never leave the service layer.
+type UserCard struct {
+	ID          int    `gorm:"column:id;primaryKey" json:"id"`
+	UserID      int    `gorm:"column:user_id;not null" json:"user_id"`
+	Token       string `gorm:"column:token" json:"token"`
+	Last4       string `gorm:"column:last4" json:"last4"`
+	Brand       string `gorm:"column:brand" json:"brand"`
+	ExpiryMonth int    `gorm:"column:expiry_month" json:"expiry_month"`
+	ExpiryYear  int    `gorm:"column:expiry_year" json:"expiry_year"`
+
+	// LegacyNumber holds a plain text number saved before the vault existed until it is sealed
+	LegacyNumber    *string `gorm:"column:card_number" json:"-"`
+	EncryptedNumber []byte  `gorm:"column:encrypted_number" json:"-"`
+	EncryptedKey    []byte  `gorm:"column:encrypted_key" json:"-"`
+	KeyID           string  `gorm:"column:key_id" json:"-"`
+	Fingerprint     string  `gorm:"column:fingerprint" json:"-"`
+	Audit
+}
+
+// TableName returns the table backing UserCard
+func (UserCard) TableName() string {
+	return "user_card_details"
+}
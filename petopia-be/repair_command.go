@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"petopia-be/db"
+	"text/tabwriter"
+)
+
+// repairUsage describes the repair-product-ids command
+const repairUsage = "usage: repair-product-ids [--apply]"
+
+// runRepairProductIDsCommand prints the product_id repair the unique index needs and applies
+// it with --apply. Without --apply nothing is written.
+func runRepairProductIDsCommand(ctx context.Context, args []string) error {
+	apply := false
+	switch {
+	case len(args) == 0:
+	case len(args) == 1 && args[0] == "--apply":
+		apply = true
+	default:
+		return errors.New(repairUsage)
+	}
+
+	database := db.GetMongoDatabase()
+	repair, err := db.PlanProductIDRepair(ctx, database)
+	if err != nil {
+		return err
+	}
+	if err := printProductIDRepair(repair); err != nil {
+		return err
+	}
+	if repair.Empty() {
+		fmt.Println("Every product has a unique product_id")
+		return nil
+	}
+	if !apply {
+		fmt.Println("Dry run: nothing was changed. Run repair-product-ids --apply to apply the removals and new ids.")
+		return nil
+	}
+
+	if err := db.RepairProductIDs(ctx, database, repair); err != nil {
+		return err
+	}
+	fmt.Println("Applied the product_id repair")
+	return nil
+}
+
+// printProductIDRepair writes a table of every product the repair touches and what happens to it
+func printProductIDRepair(repair *db.ProductIDRepair) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "_ID\tPRODUCT_ID\tACTION")
+	for _, conflict := range repair.Conflicts {
+		for _, id := range conflict.IDs {
+			fmt.Fprintf(writer, "%s\t%d\tconflict, resolve by hand\n", id.Hex(), conflict.ProductID)
+		}
+	}
+	for _, id := range repair.Remove {
+		fmt.Fprintf(writer, "%s\t\tremove exact copy\n", id.Hex())
+	}
+	for id, productID := range repair.Convert {
+		fmt.Fprintf(writer, "%s\t%d\tstore as number\n", id.Hex(), productID)
+	}
+	for _, id := range repair.Assign {
+		fmt.Fprintf(writer, "%s\t\tassign next product_id\n", id.Hex())
+	}
+	return writer.Flush()
+}
@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"petopia-be/dto"
 	mongomodels "petopia-be/models/mongo"
+	pgmodels "petopia-be/models/postgres"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -11,15 +14,26 @@ import (
 // ProductRepository defines the interface for product data operations
 type ProductRepository interface {
 	Create(ctx context.Context, product *mongomodels.ProductDetails) (*mongomodels.ProductDetails, error)
-	FindAll(ctx context.Context, filters bson.M, page, limit int64) ([]mongomodels.ProductDetails, error)
+	FindAll(ctx context.Context, filter dto.ProductFilter) ([]mongomodels.ProductDetails, int64, error)
+	FindAllByCursor(ctx context.Context, filter dto.ProductFilter) ([]mongomodels.ProductDetails, *CursorPage, error)
+	StreamAll(ctx context.Context, filter dto.ProductFilter) (ProductStream, error)
 	FindByID(ctx context.Context, id string) (*mongomodels.ProductDetails, error)
+	FindByIDIncludingDeleted(ctx context.Context, id string) (*mongomodels.ProductDetails, error)
 	FindByProductID(ctx context.Context, productID int) (*mongomodels.ProductDetails, error)
-	FindByCategory(ctx context.Context, category string) ([]mongomodels.ProductDetails, error)
-	FindAvailable(ctx context.Context, page, limit int64) ([]mongomodels.ProductDetails, int64, error)
-	Update(ctx context.Context, id string, product *mongomodels.ProductDetails) error
-	Delete(ctx context.Context, id string) error
+	FindByProductIDs(ctx context.Context, productIDs []int) ([]mongomodels.ProductDetails, error)
+	SetAvailability(ctx context.Context, productIDs []int, available bool) error
+	Update(ctx context.Context, id string, product *mongomodels.ProductDetails, expectedUpdatedAt *time.Time) error
+	Delete(ctx context.Context, id string, deletedBy int) error
+	Restore(ctx context.Context, id string) (*mongomodels.ProductDetails, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]int, error)
 	Search(ctx context.Context, term string) ([]mongomodels.ProductDetails, error)
-	Count(ctx context.Context, filter bson.M) (int64, error)
+	BulkUpsertByProductID(ctx context.Context, upserts []ProductUpsert) ([]UpsertOutcome, error)
+	FindExistingProductIDs(ctx context.Context, productIDs []int) (map[int]bool, error)
+	FindByIDs(ctx context.Context, ids []string) ([]mongomodels.ProductDetails, error)
+	BulkApply(ctx context.Context, changes []ProductChange) ([]error, error)
+	TextSearch(ctx context.Context, filter dto.ProductFilter) ([]ScoredProduct, int64, error)
+	FacetSearch(ctx context.Context, filter dto.ProductFilter, priceBoundaries []float64) (*ProductFacets, error)
+	SyncProductIDCounter(ctx context.Context) error
 	GetCollection() *mongo.Collection
 }
 
@@ -30,5 +44,93 @@ type BrandRepository interface {
 	FindByID(ctx context.Context, id string) (*mongomodels.ProductBrand, error)
 	Update(ctx context.Context, id string, brand *mongomodels.ProductBrand) error
 	Delete(ctx context.Context, id string) error
+	Count(ctx context.Context, filter bson.M) (int64, error)
 	GetCollection() *mongo.Collection
 }
+
+// CounterRepository defines the interface for atomic named sequences
+type CounterRepository interface {
+	Next(ctx context.Context, name string) (int64, error)
+	EnsureAtLeast(ctx context.Context, name string, value int64) error
+}
+
+// UserRepository defines the interface for user account and address data operations.
+// actorID is the user performing the change, recorded in the audit columns.
+type UserRepository interface {
+	Create(ctx context.Context, user *pgmodels.UserAccount) error
+	FindByID(ctx context.Context, id int) (*pgmodels.UserAccount, error)
+	Update(ctx context.Context, user *pgmodels.UserAccount, actorID int) error
+	SoftDelete(ctx context.Context, id int, actorID int) error
+	ListAddresses(ctx context.Context, userID int) ([]pgmodels.UserAddress, error)
+	FindAddress(ctx context.Context, userID, addressID int) (*pgmodels.UserAddress, error)
+	CreateAddress(ctx context.Context, address *pgmodels.UserAddress) error
+	UpdateAddress(ctx context.Context, address *pgmodels.UserAddress, actorID int) error
+	SoftDeleteAddress(ctx context.Context, userID, addressID int, actorID int) error
+}
+
+// CartRepository defines the interface for shopping cart data operations.
+// Items are addressed by user and product_id; a product appears at most once per cart.
+type CartRepository interface {
+	ListItems(ctx context.Context, userID int) ([]pgmodels.CartItem, error)
+	FindItem(ctx context.Context, userID, productID int) (*pgmodels.CartItem, error)
+	AddItem(ctx context.Context, item *pgmodels.CartItem, maxQuantity int, actorID int) error
+	UpdateQuantity(ctx context.Context, userID, productID, quantity int, actorID int) error
+	RemoveItem(ctx context.Context, userID, productID int, actorID int) error
+	Clear(ctx context.Context, userID int, actorID int) error
+}
+
+// ProductCatalogRepository defines the interface for the Postgres order catalog. Mongo
+// catalog products are registered there before cart or inventory rows reference them.
+type ProductCatalogRepository interface {
+	Register(ctx context.Context, product *mongomodels.ProductDetails, actorID int) error
+}
+
+// OrderRepository defines the interface for order data operations.
+// Orders are always returned with their products.
+type OrderRepository interface {
+	ListByUser(ctx context.Context, userID int, page, limit int64) ([]pgmodels.Order, int64, error)
+	FindByID(ctx context.Context, userID, orderID int) (*pgmodels.Order, error)
+	FindByIdempotencyKey(ctx context.Context, userID int, key string) (*pgmodels.Order, error)
+	FindItem(ctx context.Context, itemID int) (*pgmodels.OrderedProduct, error)
+	HasOrderedProduct(ctx context.Context, userID, productID int) (bool, error)
+	PlaceOrder(ctx context.Context, order *pgmodels.Order, cartItems []pgmodels.CartItem, actorID int) error
+	UpdateItemStatus(ctx context.Context, orderID, itemID int, column, from, to string, actorID int) error
+}
+
+// InventoryRepository defines the interface for per-seller, per-location stock operations.
+// Stock changes spread over a product's rows lock them until the change is written.
+type InventoryRepository interface {
+	ListByProduct(ctx context.Context, productID int) ([]pgmodels.InventoryItem, error)
+	Adjust(ctx context.Context, productID, sellerID int, location string, delta int, actorID int) error
+	Reserve(ctx context.Context, productID, quantity int, actorID int) error
+	Release(ctx context.Context, productID, quantity int, actorID int) error
+	StockLevels(ctx context.Context, productIDs []int) (map[int]StockLevel, error)
+	ListLowStock(ctx context.Context, threshold int) ([]StockLevel, error)
+}
+
+// ShippingRepository defines the interface for shipment data operations
+type ShippingRepository interface {
+	CreateShipments(ctx context.Context, shipments []*pgmodels.Shipment) error
+	FindByID(ctx context.Context, id int) (*pgmodels.Shipment, error)
+	ListByOrderedProducts(ctx context.Context, orderedProductIDs []int) ([]pgmodels.Shipment, error)
+	AssignPartner(ctx context.Context, id int, partner string, actorID int) error
+	AppendEvent(ctx context.Context, id int, event pgmodels.TrackingEvent, actorID int) error
+}
+
+// SellerRepository defines the interface for seller data operations
+type SellerRepository interface {
+	Create(ctx context.Context, seller *pgmodels.Seller) error
+	FindAll(ctx context.Context, page, limit int64) ([]pgmodels.Seller, int64, error)
+	FindByID(ctx context.Context, id int) (*pgmodels.Seller, error)
+	Update(ctx context.Context, seller *pgmodels.Seller, actorID int) error
+	SoftDelete(ctx context.Context, id int, actorID int) error
+}
+
+// CardRepository defines the interface for stored payment card operations
+type CardRepository interface {
+	Create(ctx context.Context, card *pgmodels.UserCard) error
+	ListByUser(ctx context.Context, userID int) ([]pgmodels.UserCard, error)
+	SoftDelete(ctx context.Context, userID int, token string, actorID int) error
+	ListLegacy(ctx context.Context, limit int) ([]pgmodels.UserCard, error)
+	SealLegacy(ctx context.Context, card *pgmodels.UserCard) error
+}
@@ -2,7 +2,7 @@ package repository
 
 import (
 	"context"
-	"errors"
+	"petopia-be/apperrors"
 	"petopia-be/db"
 	mongomodels "petopia-be/models/mongo"
 	"time"
@@ -13,6 +13,13 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// Brand errors
+var (
+	ErrBrandNotFound  = apperrors.NotFound("brand not found")
+	ErrInvalidBrandID = apperrors.InvalidID("invalid brand ID")
+	ErrBrandIDExists  = apperrors.Conflict("brand_id already exists")
+)
+
 // MongoBrandRepository implements BrandRepository using MongoDB
 type MongoBrandRepository struct {
 	collection *mongo.Collection
@@ -36,6 +43,9 @@ func (r *MongoBrandRepository) Create(ctx context.Context, brand *mongomodels.Pr
 
 	result, err := r.collection.InsertOne(ctx, brand)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrBrandIDExists
+		}
 		return nil, err
 	}
 
@@ -46,7 +56,10 @@ func (r *MongoBrandRepository) Create(ctx context.Context, brand *mongomodels.Pr
 // FindAll returns all brands matching the filters
 func (r *MongoBrandRepository) FindAll(ctx context.Context, filters bson.M, page, limit int64) ([]mongomodels.ProductBrand, error) {
 	skip := (page - 1) * limit
-	options := options.Find().SetSkip(skip).SetLimit(limit)
+	options := options.Find().
+		SetSort(bson.D{{Key: "brand_name", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(limit)
 
 	cursor, err := r.collection.Find(ctx, filters, options)
 	if err != nil {
@@ -66,14 +79,14 @@ func (r *MongoBrandRepository) FindAll(ctx context.Context, filters bson.M, page
 func (r *MongoBrandRepository) FindByID(ctx context.Context, id string) (*mongomodels.ProductBrand, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, errors.New("invalid brand ID")
+		return nil, ErrInvalidBrandID
 	}
 
 	var brand mongomodels.ProductBrand
 	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&brand)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("brand not found")
+			return nil, ErrBrandNotFound
 		}
 		return nil, err
 	}
@@ -85,7 +98,7 @@ func (r *MongoBrandRepository) FindByID(ctx context.Context, id string) (*mongom
 func (r *MongoBrandRepository) Update(ctx context.Context, id string, brand *mongomodels.ProductBrand) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("invalid brand ID")
+		return ErrInvalidBrandID
 	}
 
 	brand.UpdatedAt = time.Now()
@@ -109,7 +122,7 @@ func (r *MongoBrandRepository) Update(ctx context.Context, id string, brand *mon
 	}
 
 	if result.MatchedCount == 0 {
-		return errors.New("brand not found")
+		return ErrBrandNotFound
 	}
 
 	return nil
@@ -119,7 +132,7 @@ func (r *MongoBrandRepository) Update(ctx context.Context, id string, brand *mon
 func (r *MongoBrandRepository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("invalid brand ID")
+		return ErrInvalidBrandID
 	}
 
 	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
@@ -128,8 +141,13 @@ func (r *MongoBrandRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	if result.DeletedCount == 0 {
-		return errors.New("brand not found")
+		return ErrBrandNotFound
 	}
 
 	return nil
 }
+
+// Count returns the number of brands matching a filter
+func (r *MongoBrandRepository) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return r.collection.CountDocuments(ctx, filter)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"petopia-be/db"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProductIDSequence is the counter used to assign product_id values
+const ProductIDSequence = db.ProductIDSequence
+
+// MongoCounterRepository implements CounterRepository using a MongoDB counters collection
+// holding one {_id: name, seq: n} document per sequence
+type MongoCounterRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoCounterRepository creates a new MongoDB counter repository
+func NewMongoCounterRepository() CounterRepository {
+	collection := db.GetMongoCollection("counters")
+	return &MongoCounterRepository{collection: collection}
+}
+
+// Next atomically increments the sequence and returns its new value, starting at 1
+func (r *MongoCounterRepository) Next(ctx context.Context, name string) (int64, error) {
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": name}, bson.M{"$inc": bson.M{"seq": 1}}, opts).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+
+	return counter.Seq, nil
+}
+
+// EnsureAtLeast raises the sequence to value if it is currently lower, so the next
+// value handed out is greater than any id assigned outside the counter
+func (r *MongoCounterRepository) EnsureAtLeast(ctx context.Context, name string, value int64) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": name},
+		bson.M{"$max": bson.M{"seq": value}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
-	"errors"
+	"petopia-be/apperrors"
 	"petopia-be/db"
+	"petopia-be/dto"
 	mongomodels "petopia-be/models/mongo"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -13,28 +15,52 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// Error constants to avoid duplication
-const (
-	ErrProductNotFound  = "product not found"
-	ErrInvalidProductID = "invalid product ID"
+// Product errors shared with the service and controller layers
+var (
+	ErrProductNotFound  = apperrors.NotFound("product not found")
+	ErrInvalidProductID = apperrors.InvalidID("invalid product ID")
+	ErrInvalidSortField = apperrors.BadRequest("invalid sort field")
+	ErrInvalidSortOrder = apperrors.BadRequest("invalid sort order")
+	ErrProductModified  = apperrors.PreconditionFailed("product was modified by another request")
+	ErrProductIDExists  = apperrors.Conflict("product_id already exists")
 )
 
+// ErrProductNotDeleted is returned when restoring a product that is not soft deleted
+var ErrProductNotDeleted = apperrors.Conflict("product is not deleted")
+
+// productSortFields whitelists the fields products can be sorted by
+var productSortFields = map[string]string{
+	"product_name": "product_name",
+	"price":        "price",
+	"discount":     "discount",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+}
+
+// notDeleted matches products that have not been soft deleted, including documents
+// written before the is_deleted field existed
+var notDeleted = bson.M{"$ne": true}
+
 // MongoDB regex constants
 const (
-	RegexKey     = "$regex"
-	OptionsKey   = "$options"
-	CaseOption   = "i" // Case insensitive
+	RegexKey   = "$regex"
+	OptionsKey = "$options"
+	CaseOption = "i" // Case insensitive
 )
 
 // MongoProductRepository implements ProductRepository using MongoDB
 type MongoProductRepository struct {
 	collection *mongo.Collection
+	counters   CounterRepository
 }
 
 // NewMongoProductRepository creates a new MongoDB product repository
 func NewMongoProductRepository() ProductRepository {
 	collection := db.GetMongoCollection("products")
-	return &MongoProductRepository{collection: collection}
+	return &MongoProductRepository{
+		collection: collection,
+		counters:   NewMongoCounterRepository(),
+	}
 }
 
 // GetCollection returns the MongoDB collection
@@ -42,13 +68,27 @@ func (r *MongoProductRepository) GetCollection() *mongo.Collection {
 	return r.collection
 }
 
-// Create inserts a new product
+// Create inserts a new product, assigning the next sequential product_id when none is set
 func (r *MongoProductRepository) Create(ctx context.Context, product *mongomodels.ProductDetails) (*mongomodels.ProductDetails, error) {
+	if product.ProductID == 0 {
+		productID, err := r.counters.Next(ctx, ProductIDSequence)
+		if err != nil {
+			return nil, err
+		}
+		product.ProductID = int(productID)
+	}
+
 	product.CreatedAt = time.Now()
 	product.UpdatedAt = time.Now()
+	product.IsDeleted = false
+	product.DeletedAt = nil
+	product.DeletedBy = 0
 
 	result, err := r.collection.InsertOne(ctx, product)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrProductIDExists
+		}
 		return nil, err
 	}
 
@@ -56,37 +96,138 @@ func (r *MongoProductRepository) Create(ctx context.Context, product *mongomodel
 	return product, nil
 }
 
-// FindAll returns all products matching the filters
-func (r *MongoProductRepository) FindAll(ctx context.Context, filters bson.M, page, limit int64) ([]mongomodels.ProductDetails, error) {
-	skip := (page - 1) * limit
-	options := options.Find().SetSkip(skip).SetLimit(limit)
+// FindAll returns a page of products matching the filter along with the total match count
+func (r *MongoProductRepository) FindAll(ctx context.Context, filter dto.ProductFilter) ([]mongomodels.ProductDetails, int64, error) {
+	sort, err := buildProductSort(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := buildProductQuery(filter)
 
-	cursor, err := r.collection.Find(ctx, filters, options)
+	total, err := r.collection.CountDocuments(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	skip := (filter.Page - 1) * filter.Limit
+	options := options.Find().
+		SetSort(sort).
+		SetSkip(skip).
+		SetLimit(filter.Limit)
+
+	cursor, err := r.collection.Find(ctx, query, options)
+	if err != nil {
+		return nil, 0, err
 	}
 	defer cursor.Close(ctx)
 
 	var products []mongomodels.ProductDetails
 	if err := cursor.All(ctx, &products); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return products, nil
+	return products, total, nil
 }
 
-// FindByID returns a product by its ID
+// buildProductQuery converts a ProductFilter into a MongoDB query document
+func buildProductQuery(filter dto.ProductFilter) bson.M {
+	query := bson.M{}
+
+	if !filter.IncludeDeleted {
+		query["is_deleted"] = notDeleted
+	}
+	if filter.Category != "" {
+		query["category"] = filter.Category
+	}
+	if filter.BrandID > 0 {
+		query["brand_id"] = filter.BrandID
+	}
+	if filter.SellerID > 0 {
+		query["seller_id"] = filter.SellerID
+	}
+	if filter.Availability != nil {
+		query["availability"] = *filter.Availability
+	}
+
+	priceRange := bson.M{}
+	if filter.MinPrice != nil {
+		priceRange["$gte"] = *filter.MinPrice
+	}
+	if filter.MaxPrice != nil {
+		priceRange["$lte"] = *filter.MaxPrice
+	}
+	if len(priceRange) > 0 {
+		query["price"] = priceRange
+	}
+
+	if filter.MinDiscount != nil {
+		query["discount"] = bson.M{"$gte": *filter.MinDiscount}
+	}
+
+	if filter.SearchTerm != "" {
+		// Match the term literally so it cannot inject a regular expression
+		pattern := regexp.QuoteMeta(filter.SearchTerm)
+		query["$or"] = []bson.M{
+			{"product_name": bson.M{RegexKey: pattern, OptionsKey: CaseOption}},
+			{"description": bson.M{RegexKey: pattern, OptionsKey: CaseOption}},
+			{"category": bson.M{RegexKey: pattern, OptionsKey: CaseOption}},
+			{"brand_name": bson.M{RegexKey: pattern, OptionsKey: CaseOption}},
+		}
+	}
+
+	return query
+}
+
+// buildProductSort converts the whitelisted sort field and order of a ProductFilter into a sort document
+func buildProductSort(filter dto.ProductFilter) (bson.D, error) {
+	field := "created_at"
+	if filter.SortBy != "" {
+		whitelisted, ok := productSortFields[filter.SortBy]
+		if !ok {
+			return nil, ErrInvalidSortField
+		}
+		field = whitelisted
+	}
+
+	order := -1
+	switch filter.SortOrder {
+	case "", "desc":
+	case "asc":
+		order = 1
+	default:
+		return nil, ErrInvalidSortOrder
+	}
+
+	// Sort on _id as well so pages stay stable when sort values tie
+	return bson.D{{Key: field, Value: order}, {Key: "_id", Value: order}}, nil
+}
+
+// FindByID returns a product by its ID unless it has been soft deleted
 func (r *MongoProductRepository) FindByID(ctx context.Context, id string) (*mongomodels.ProductDetails, error) {
+	return r.findByID(ctx, id, false)
+}
+
+// FindByIDIncludingDeleted returns a product by its ID even if it has been soft deleted
+func (r *MongoProductRepository) FindByIDIncludingDeleted(ctx context.Context, id string) (*mongomodels.ProductDetails, error) {
+	return r.findByID(ctx, id, true)
+}
+
+func (r *MongoProductRepository) findByID(ctx context.Context, id string, includeDeleted bool) (*mongomodels.ProductDetails, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, errors.New("invalid product ID")
+		return nil, ErrInvalidProductID
+	}
+
+	filter := bson.M{"_id": objectID}
+	if !includeDeleted {
+		filter["is_deleted"] = notDeleted
 	}
 
 	var product mongomodels.ProductDetails
-	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&product)
+	err = r.collection.FindOne(ctx, filter).Decode(&product)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New(ErrProductNotFound)
+			return nil, ErrProductNotFound
 		}
 		return nil, err
 	}
@@ -94,128 +235,209 @@ func (r *MongoProductRepository) FindByID(ctx context.Context, id string) (*mong
 	return &product, nil
 }
 
-// Update modifies an existing product
-func (r *MongoProductRepository) Update(ctx context.Context, id string, product *mongomodels.ProductDetails) error {
+// Update modifies an existing, not deleted product. When expectedUpdatedAt is set the update only
+// applies if the stored updated_at still matches, guarding against lost updates.
+func (r *MongoProductRepository) Update(ctx context.Context, id string, product *mongomodels.ProductDetails, expectedUpdatedAt *time.Time) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New(ErrInvalidProductID)
+		return ErrInvalidProductID
 	}
 
 	product.UpdatedAt = time.Now()
-	update := bson.M{
-		"$set": bson.M{
-			"product_name":    product.ProductName,
-			"description":     product.Description,
-			"brand_id":        product.BrandID,
-			"brand_name":      product.BrandName,
-			"seller_id":       product.SellerID,
-			"category":        product.Category,
-			"item_dimensions": product.ItemDimensions,
-			"price":           product.Price,
-			"discount":        product.Discount,
-			"availability":    product.Availability,
-			"updated_at":      product.UpdatedAt,
-		},
+	update := bson.M{"$set": productUpdateFields(product)}
+
+	filter := bson.M{"_id": objectID, "is_deleted": notDeleted}
+	if expectedUpdatedAt != nil {
+		filter["updated_at"] = time.UnixMilli(expectedUpdatedAt.UnixMilli())
 	}
 
-	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 
 	if result.MatchedCount == 0 {
-		return errors.New(ErrProductNotFound)
+		if expectedUpdatedAt != nil {
+			exists, err := r.collection.CountDocuments(ctx, bson.M{"_id": objectID, "is_deleted": notDeleted})
+			if err != nil {
+				return err
+			}
+			if exists > 0 {
+				return ErrProductModified
+			}
+		}
+		return ErrProductNotFound
 	}
 
 	return nil
 }
 
-// Delete removes a product
-func (r *MongoProductRepository) Delete(ctx context.Context, id string) error {
+// productUpdateFields returns the client editable fields of a product as a $set document
+func productUpdateFields(product *mongomodels.ProductDetails) bson.M {
+	return bson.M{
+		"product_name":    product.ProductName,
+		"description":     product.Description,
+		"brand_id":        product.BrandID,
+		"brand_name":      product.BrandName,
+		"seller_id":       product.SellerID,
+		"category":        product.Category,
+		"item_dimensions": product.ItemDimensions,
+		"price":           product.Price,
+		"discount":        product.Discount,
+		"availability":    product.Availability,
+		"updated_at":      product.UpdatedAt,
+	}
+}
+
+// Delete soft deletes a product, recording when and by whom. The document is kept until
+// PurgeDeleted removes it after the retention period.
+func (r *MongoProductRepository) Delete(ctx context.Context, id string, deletedBy int) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New(ErrInvalidProductID)
+		return ErrInvalidProductID
 	}
 
-	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	now := time.Now()
+	set := bson.M{
+		"is_deleted": true,
+		"deleted_at": now,
+		"updated_at": now,
+	}
+	if deletedBy > 0 {
+		set["deleted_by"] = deletedBy
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "is_deleted": notDeleted}, bson.M{"$set": set})
 	if err != nil {
 		return err
 	}
 
-	if result.DeletedCount == 0 {
-		return errors.New(ErrProductNotFound)
+	if result.MatchedCount == 0 {
+		return ErrProductNotFound
 	}
 
 	return nil
 }
 
-// FindByProductID retrieves a product by its product_id
-func (r *MongoProductRepository) FindByProductID(ctx context.Context, productID int) (*mongomodels.ProductDetails, error) {
+// Restore undoes a soft delete and returns the restored product
+func (r *MongoProductRepository) Restore(ctx context.Context, id string) (*mongomodels.ProductDetails, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidProductID
+	}
+
+	update := bson.M{
+		"$set":   bson.M{"is_deleted": false, "updated_at": time.Now()},
+		"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
 	var product mongomodels.ProductDetails
-	err := r.collection.FindOne(ctx, bson.M{"product_id": productID}).Decode(&product)
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID, "is_deleted": true}, update, opts).Decode(&product)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("product not found")
+		if err != mongo.ErrNoDocuments {
+			return nil, err
 		}
-		return nil, err
+		exists, err := r.collection.CountDocuments(ctx, bson.M{"_id": objectID})
+		if err != nil {
+			return nil, err
+		}
+		if exists > 0 {
+			return nil, ErrProductNotDeleted
+		}
+		return nil, ErrProductNotFound
 	}
+
 	return &product, nil
 }
 
-// FindByCategory retrieves products by category
-func (r *MongoProductRepository) FindByCategory(ctx context.Context, category string) ([]mongomodels.ProductDetails, error) {
-	filter := bson.M{"category": category, "availability": true}
+// PurgeDeleted permanently removes products soft deleted before the cutoff and returns
+// the product_ids that were removed
+func (r *MongoProductRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]int, error) {
+	filter := bson.M{"is_deleted": true, "deleted_at": bson.M{"$lt": deletedBefore}}
 
-	cursor, err := r.collection.Find(ctx, filter)
+	opts := options.Find().SetProjection(bson.M{"product_id": 1})
+	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var products []mongomodels.ProductDetails
-	if err := cursor.All(ctx, &products); err != nil {
+	var expired []struct {
+		ProductID int `bson:"product_id"`
+	}
+	if err := cursor.All(ctx, &expired); err != nil {
 		return nil, err
 	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
 
-	return products, nil
-}
+	productIDs := make([]int, len(expired))
+	for i, product := range expired {
+		productIDs[i] = product.ProductID
+	}
 
-// FindAvailable retrieves all available products with pagination
-func (r *MongoProductRepository) FindAvailable(ctx context.Context, page, limit int64) ([]mongomodels.ProductDetails, int64, error) {
-	filter := bson.M{"availability": true}
+	// Re-check the deletion state so a product restored in the meantime is kept
+	filter["product_id"] = bson.M{"$in": productIDs}
+	if _, err := r.collection.DeleteMany(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	return productIDs, nil
+}
 
-	// Get total count for pagination
-	total, err := r.collection.CountDocuments(ctx, filter)
+// FindByProductID retrieves a product by its product_id
+func (r *MongoProductRepository) FindByProductID(ctx context.Context, productID int) (*mongomodels.ProductDetails, error) {
+	var product mongomodels.ProductDetails
+	err := r.collection.FindOne(ctx, bson.M{"product_id": productID, "is_deleted": notDeleted}).Decode(&product)
 	if err != nil {
-		return nil, 0, err
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
 	}
+	return &product, nil
+}
 
-	// Configure pagination
-	skip := (page - 1) * limit
-	opts := options.Find().
-		SetSort(bson.D{{Key: "created_at", Value: -1}}).
-		SetSkip(skip).
-		SetLimit(limit)
-
-	cursor, err := r.collection.Find(ctx, filter, opts)
+// FindByProductIDs returns the products among the given product_ids that exist and are not deleted
+func (r *MongoProductRepository) FindByProductIDs(ctx context.Context, productIDs []int) ([]mongomodels.ProductDetails, error) {
+	filter := bson.M{"product_id": bson.M{"$in": productIDs}, "is_deleted": notDeleted}
+	cursor, err := r.collection.Find(ctx, filter)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer cursor.Close(ctx)
 
 	var products []mongomodels.ProductDetails
 	if err := cursor.All(ctx, &products); err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	return products, total, nil
+	return products, nil
+}
+
+// SetAvailability sets the availability of the not deleted products with the given product_ids,
+// leaving products that already have that availability untouched
+func (r *MongoProductRepository) SetAvailability(ctx context.Context, productIDs []int, available bool) error {
+	filter := bson.M{
+		"product_id":   bson.M{"$in": productIDs},
+		"availability": bson.M{"$ne": available},
+		"is_deleted":   notDeleted,
+	}
+	update := bson.M{"$set": bson.M{"availability": available, "updated_at": time.Now()}}
+
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
 }
 
 // Search finds products by name, description, etc.
 func (r *MongoProductRepository) Search(ctx context.Context, term string) ([]mongomodels.ProductDetails, error) {
+	term = regexp.QuoteMeta(term)
 	filter := bson.M{
 		"$and": []bson.M{
 			{"availability": true},
+			{"is_deleted": notDeleted},
 			{
 				"$or": []bson.M{
 					{"product_name": bson.M{"$regex": term, "$options": "i"}},
@@ -241,7 +463,49 @@ func (r *MongoProductRepository) Search(ctx context.Context, term string) ([]mon
 	return products, nil
 }
 
-// Count returns the number of documents matching a filter
-func (r *MongoProductRepository) Count(ctx context.Context, filter bson.M) (int64, error) {
-	return r.collection.CountDocuments(ctx, filter)
+// ScoredProduct is a product returned by a relevance-ranked search together with its text score
+type ScoredProduct struct {
+	mongomodels.ProductDetails `bson:",inline"`
+	Score                      float64 `bson:"score"`
+}
+
+// TextSearch finds products through the weighted text index, ordered by relevance
+func (r *MongoProductRepository) TextSearch(ctx context.Context, filter dto.ProductFilter) ([]ScoredProduct, int64, error) {
+	term := filter.SearchTerm
+	filter.SearchTerm = ""
+
+	query := buildProductQuery(filter)
+	query["$text"] = bson.M{"$search": term}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	textScore := bson.M{"$meta": "textScore"}
+	skip := (filter.Page - 1) * filter.Limit
+	opts := options.Find().
+		SetProjection(bson.M{"score": textScore}).
+		SetSort(bson.D{{Key: "score", Value: textScore}, {Key: "_id", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(filter.Limit)
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var products []ScoredProduct
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
+}
+
+// SyncProductIDCounter moves the product_id sequence past the highest product_id already
+// stored, e.g. by seeding or imports, so assigned ids never collide with existing ones
+func (r *MongoProductRepository) SyncProductIDCounter(ctx context.Context) error {
+	return db.SyncProductIDCounter(ctx, r.collection.Database())
 }
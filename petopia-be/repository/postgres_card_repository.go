@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	pgmodels "petopia-be/models/postgres"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Card errors shared with the service and controller layers
+var (
+	ErrCardNotFound  = apperrors.NotFound("card not found")
+	ErrDuplicateCard = apperrors.Conflict("card is already saved")
+)
+
+// PostgresCardRepository implements CardRepository using GORM
+type PostgresCardRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresCardRepository creates a new Postgres card repository
+func NewPostgresCardRepository(db *gorm.DB) CardRepository {
+	return &PostgresCardRepository{db: db}
+}
+
+// Create inserts a new sealed card
+func (r *PostgresCardRepository) Create(ctx context.Context, card *pgmodels.UserCard) error {
+	err := r.db.WithContext(ctx).Create(card).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicateCard
+	}
+	return err
+}
+
+// ListByUser returns the sealed cards of a user that have not been deleted, oldest first.
+// Legacy cards are left out until they are sealed.
+func (r *PostgresCardRepository) ListByUser(ctx context.Context, userID int) ([]pgmodels.UserCard, error) {
+	var cards []pgmodels.UserCard
+	err := r.db.WithContext(ctx).
+		Scopes(notDeletedScope).
+		Where("user_id = ? AND token IS NOT NULL", userID).
+		Order("id").
+		Find(&cards).Error
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// SoftDelete marks a card of a user as deleted
+func (r *PostgresCardRepository) SoftDelete(ctx context.Context, userID int, token string, actorID int) error {
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.UserCard{}).
+		Scopes(notDeletedScope).
+		Where("user_id = ? AND token = ?", userID, token).
+		Updates(map[string]interface{}{
+			"is_deleted": true,
+			"deleted_at": time.Now(),
+			"deleted_by": pgmodels.ActorRef(actorID),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCardNotFound
+	}
+	return nil
+}
+
+// ListLegacy returns up to limit cards, deleted or not, that still hold a plain text number
+func (r *PostgresCardRepository) ListLegacy(ctx context.Context, limit int) ([]pgmodels.UserCard, error) {
+	var cards []pgmodels.UserCard
+	err := r.db.WithContext(ctx).
+		Where("card_number IS NOT NULL").
+		Order("id").
+		Limit(limit).
+		Find(&cards).Error
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// SealLegacy stores the sealed values of a legacy card and clears its plain text number.
+// A card marked deleted is soft deleted in the same write.
+func (r *PostgresCardRepository) SealLegacy(ctx context.Context, card *pgmodels.UserCard) error {
+	changes := map[string]interface{}{
+		"card_number":      nil,
+		"token":            card.Token,
+		"last4":            card.Last4,
+		"brand":            card.Brand,
+		"encrypted_number": card.EncryptedNumber,
+		"encrypted_key":    card.EncryptedKey,
+		"key_id":           card.KeyID,
+		"fingerprint":      card.Fingerprint,
+		"is_deleted":       card.IsDeleted,
+	}
+	if card.IsDeleted && card.DeletedAt == nil {
+		changes["deleted_at"] = time.Now()
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.UserCard{}).
+		Where("id = ? AND card_number IS NOT NULL", card.ID).
+		Updates(changes)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return ErrDuplicateCard
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCardNotFound
+	}
+
+	card.LegacyNumber = nil
+	return nil
+}
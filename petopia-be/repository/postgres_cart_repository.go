@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	pgmodels "petopia-be/models/postgres"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Cart errors shared with the service and controller layers
+var (
+	ErrCartItemNotFound    = apperrors.NotFound("cart item not found")
+	ErrProductNotOrderable = apperrors.Conflict("product is not registered in the order catalog")
+	ErrCartQuantityLimit   = apperrors.Conflict("cart item quantity limit reached")
+)
+
+// PostgresCartRepository implements CartRepository using GORM
+type PostgresCartRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresCartRepository creates a new Postgres cart repository
+func NewPostgresCartRepository(db *gorm.DB) CartRepository {
+	return &PostgresCartRepository{db: db}
+}
+
+// ListItems returns the items in a user's cart, oldest first
+func (r *PostgresCartRepository) ListItems(ctx context.Context, userID int) ([]pgmodels.CartItem, error) {
+	var items []pgmodels.CartItem
+	err := r.db.WithContext(ctx).
+		Scopes(notDeletedScope).
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindItem returns the cart item of a user for a product
+func (r *PostgresCartRepository) FindItem(ctx context.Context, userID, productID int) (*pgmodels.CartItem, error) {
+	var item pgmodels.CartItem
+	err := r.db.WithContext(ctx).
+		Scopes(notDeletedScope).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCartItemNotFound
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// AddItem inserts a cart item, or adds its quantity to the user's cart line for the product
+// in one statement so concurrent adds never create a second line. An add that would take the
+// line past maxQuantity is not written and fails with ErrCartQuantityLimit.
+func (r *PostgresCartRepository) AddItem(ctx context.Context, item *pgmodels.CartItem, maxQuantity int, actorID int) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "user_id"}, {Name: "product_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{gorm.Expr("is_deleted = FALSE")}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"quantity":   gorm.Expr("cart.quantity + EXCLUDED.quantity"),
+				"updated_at": time.Now(),
+				"updated_by": pgmodels.ActorRef(actorID),
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				gorm.Expr("cart.quantity + EXCLUDED.quantity <= ?", maxQuantity),
+			}},
+		}).
+		Create(item)
+	if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+		return ErrProductNotOrderable
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCartQuantityLimit
+	}
+	return nil
+}
+
+// UpdateQuantity sets the quantity of a user's cart item for a product
+func (r *PostgresCartRepository) UpdateQuantity(ctx context.Context, userID, productID, quantity int, actorID int) error {
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.CartItem{}).
+		Scopes(notDeletedScope).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Updates(map[string]interface{}{
+			"quantity":   quantity,
+			"updated_at": time.Now(),
+			"updated_by": pgmodels.ActorRef(actorID),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCartItemNotFound
+	}
+	return nil
+}
+
+// RemoveItem soft deletes a user's cart item for a product
+func (r *PostgresCartRepository) RemoveItem(ctx context.Context, userID, productID int, actorID int) error {
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.CartItem{}).
+		Scopes(notDeletedScope).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Updates(cartDeletion(actorID))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCartItemNotFound
+	}
+	return nil
+}
+
+// Clear soft deletes every item in a user's cart
+func (r *PostgresCartRepository) Clear(ctx context.Context, userID int, actorID int) error {
+	return r.db.WithContext(ctx).
+		Model(&pgmodels.CartItem{}).
+		Scopes(notDeletedScope).
+		Where("user_id = ?", userID).
+		Updates(cartDeletion(actorID)).Error
+}
+
+// cartDeletion returns the column values that soft delete cart items
+func cartDeletion(actorID int) map[string]interface{} {
+	return map[string]interface{}{
+		"is_deleted": true,
+		"deleted_at": time.Now(),
+		"deleted_by": pgmodels.ActorRef(actorID),
+	}
+}
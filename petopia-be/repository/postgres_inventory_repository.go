@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	pgmodels "petopia-be/models/postgres"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Inventory errors shared with the service and controller layers
+var (
+	ErrInventoryReference     = apperrors.Conflict("product or seller is not registered")
+	ErrInventoryChanged       = apperrors.Conflict("stock changed concurrently, please try again")
+	ErrReleaseExceedsReserved = apperrors.Conflict("cannot release more stock than is reserved")
+)
+
+// StockLevel is the stock of a product summed over all of its sellers and locations
+type StockLevel struct {
+	ProductID int `gorm:"column:product_id"`
+	Quantity  int `gorm:"column:quantity"`
+	Reserved  int `gorm:"column:reserved"`
+}
+
+// Available returns the units that can still be sold
+func (l StockLevel) Available() int {
+	return l.Quantity - l.Reserved
+}
+
+// PostgresInventoryRepository implements InventoryRepository using GORM
+type PostgresInventoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresInventoryRepository creates a new Postgres inventory repository
+func NewPostgresInventoryRepository(db *gorm.DB) InventoryRepository {
+	return &PostgresInventoryRepository{db: db}
+}
+
+// ListByProduct returns the stock rows of a product that have not been deleted
+func (r *PostgresInventoryRepository) ListByProduct(ctx context.Context, productID int) ([]pgmodels.InventoryItem, error) {
+	var items []pgmodels.InventoryItem
+	err := r.db.WithContext(ctx).
+		Scopes(notDeletedScope).
+		Where("product_id = ?", productID).
+		Order("seller_id, inventory_location").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Adjust adds delta units, which may be negative, to the stock a seller holds of a product at
+// a location. The first positive adjustment creates the row. Stock cannot drop below what is reserved.
+func (r *PostgresInventoryRepository) Adjust(ctx context.Context, productID, sellerID int, location string, delta int, actorID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item pgmodels.InventoryItem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(notDeletedScope).
+			Where("product_id = ? AND seller_id = ? AND inventory_location = ?", productID, sellerID, location).
+			First(&item).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if delta < 0 {
+				return insufficientStockError(productID, 0)
+			}
+			item = pgmodels.InventoryItem{
+				ProductID:         productID,
+				SellerID:          sellerID,
+				InventoryLocation: location,
+				Quantity:          delta,
+			}
+			item.CreatedBy = pgmodels.ActorRef(actorID)
+
+			err := tx.Create(&item).Error
+			switch {
+			case errors.Is(err, gorm.ErrForeignKeyViolated):
+				return ErrInventoryReference
+			case errors.Is(err, gorm.ErrDuplicatedKey):
+				return ErrInventoryChanged
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if item.Available()+delta < 0 {
+			return insufficientStockError(productID, item.Available())
+		}
+		return tx.Model(&pgmodels.InventoryItem{}).
+			Where("id = ?", item.ID).
+			Updates(stockUpdate("quantity", delta, actorID)).Error
+	})
+}
+
+// Reserve holds quantity units of a product for customers, drawing from the rows with the
+// most sellable stock first
+func (r *PostgresInventoryRepository) Reserve(ctx context.Context, productID, quantity int, actorID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return drawSellableStock(tx, productID, quantity, "reserved", 1, actorID)
+	})
+}
+
+// Release returns quantity reserved units of a product to sellable stock, drawing from the
+// rows with the most reserved stock first
+func (r *PostgresInventoryRepository) Release(ctx context.Context, productID, quantity int, actorID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stock []pgmodels.InventoryItem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(notDeletedScope).
+			Where("product_id = ? AND reserved > 0", productID).
+			Order("reserved DESC, id").
+			Find(&stock).Error
+		if err != nil {
+			return err
+		}
+
+		reserved := 0
+		for _, row := range stock {
+			reserved += row.Reserved
+		}
+		if reserved < quantity {
+			return &apperrors.Error{
+				Kind:    apperrors.ErrConflict,
+				Message: ErrReleaseExceedsReserved.Message,
+				Details: map[string]int{"product_id": productID, "reserved": reserved},
+			}
+		}
+
+		remaining := quantity
+		for _, row := range stock {
+			if remaining == 0 {
+				break
+			}
+			released := min(row.Reserved, remaining)
+			err := tx.Model(&pgmodels.InventoryItem{}).
+				Where("id = ?", row.ID).
+				Updates(stockUpdate("reserved", -released, actorID)).Error
+			if err != nil {
+				return err
+			}
+			remaining -= released
+		}
+		return nil
+	})
+}
+
+// StockLevels returns the summed stock of each given product that has inventory rows
+func (r *PostgresInventoryRepository) StockLevels(ctx context.Context, productIDs []int) (map[int]StockLevel, error) {
+	var levels []StockLevel
+	err := r.stockLevelQuery(ctx).
+		Where("product_id IN ?", productIDs).
+		Scan(&levels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	levelsByProduct := make(map[int]StockLevel, len(levels))
+	for _, level := range levels {
+		levelsByProduct[level.ProductID] = level
+	}
+	return levelsByProduct, nil
+}
+
+// ListLowStock returns the products whose sellable stock is at or below threshold, lowest first
+func (r *PostgresInventoryRepository) ListLowStock(ctx context.Context, threshold int) ([]StockLevel, error) {
+	var levels []StockLevel
+	err := r.stockLevelQuery(ctx).
+		Having("SUM(quantity - reserved) <= ?", threshold).
+		Order("SUM(quantity - reserved), product_id").
+		Scan(&levels).Error
+	if err != nil {
+		return nil, err
+	}
+	return levels, nil
+}
+
+// stockLevelQuery sums the stock rows that have not been deleted per product
+func (r *PostgresInventoryRepository) stockLevelQuery(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&pgmodels.InventoryItem{}).
+		Scopes(notDeletedScope).
+		Select("product_id, SUM(quantity) AS quantity, SUM(reserved) AS reserved").
+		Group("product_id")
+}
+
+// takeStock removes quantity sellable units of a product from inventory inside a transaction.
+// Reserved units are left untouched. A product without inventory rows is not stock-tracked
+// and is sold without drawing stock, matching the availability rule of the cart.
+func takeStock(tx *gorm.DB, productID, quantity int, actorID int) error {
+	var rows int64
+	err := tx.Model(&pgmodels.InventoryItem{}).
+		Scopes(notDeletedScope).
+		Where("product_id = ?", productID).
+		Count(&rows).Error
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return nil
+	}
+	return drawSellableStock(tx, productID, quantity, "quantity", -1, actorID)
+}
+
+// drawSellableStock spreads quantity sellable units of a product over its rows, most sellable
+// stock first, adding sign times each row's share to column. The rows stay locked until the
+// transaction ends.
+func drawSellableStock(tx *gorm.DB, productID, quantity int, column string, sign int, actorID int) error {
+	var stock []pgmodels.InventoryItem
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(notDeletedScope).
+		Where("product_id = ? AND quantity > reserved", productID).
+		Order("quantity - reserved DESC, id").
+		Find(&stock).Error
+	if err != nil {
+		return err
+	}
+
+	available := 0
+	for _, row := range stock {
+		available += row.Available()
+	}
+	if available < quantity {
+		return insufficientStockError(productID, available)
+	}
+
+	remaining := quantity
+	for _, row := range stock {
+		if remaining == 0 {
+			break
+		}
+		share := min(row.Available(), remaining)
+		err := tx.Model(&pgmodels.InventoryItem{}).
+			Where("id = ?", row.ID).
+			Updates(stockUpdate(column, sign*share, actorID)).Error
+		if err != nil {
+			return err
+		}
+		remaining -= share
+	}
+	return nil
+}
+
+// stockUpdate returns the column values that add delta to a stock column
+func stockUpdate(column string, delta int, actorID int) map[string]interface{} {
+	return map[string]interface{}{
+		column:       gorm.Expr(column+" + ?", delta),
+		"updated_at": time.Now(),
+		"updated_by": pgmodels.ActorRef(actorID),
+	}
+}
+
+// insufficientStockError reports a product whose sellable stock cannot cover a request
+func insufficientStockError(productID, available int) error {
+	return &apperrors.Error{
+		Kind:    apperrors.ErrConflict,
+		Message: "insufficient stock",
+		Details: map[string]int{
+			"product_id": productID,
+			"available":  available,
+		},
+	}
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	pgmodels "petopia-be/models/postgres"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Order errors shared with the service and controller layers
+var (
+	ErrOrderNotFound           = apperrors.NotFound("order not found")
+	ErrOrderItemNotFound       = apperrors.NotFound("ordered product not found")
+	ErrOrderItemChanged        = apperrors.Conflict("ordered product status changed concurrently, please try again")
+	ErrCartChanged             = apperrors.Conflict("cart changed during checkout, please review it and try again")
+	ErrDuplicateIdempotencyKey = apperrors.Conflict("an order was already placed with this idempotency key")
+)
+
+// PostgresOrderRepository implements OrderRepository using GORM
+type PostgresOrderRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresOrderRepository creates a new Postgres order repository
+func NewPostgresOrderRepository(db *gorm.DB) OrderRepository {
+	return &PostgresOrderRepository{db: db}
+}
+
+// ListByUser returns a page of the orders of a user that have not been deleted, newest first,
+// and the total number of such orders
+func (r *PostgresOrderRepository) ListByUser(ctx context.Context, userID int, page, limit int64) ([]pgmodels.Order, int64, error) {
+	userOrders := func() *gorm.DB {
+		return r.db.WithContext(ctx).
+			Model(&pgmodels.Order{}).
+			Scopes(notDeletedScope).
+			Where("user_id = ?", userID)
+	}
+
+	var total int64
+	if err := userOrders().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var orders []pgmodels.Order
+	err := userOrders().
+		Scopes(preloadOrderItems).
+		Order("created_at DESC, id DESC").
+		Offset(int((page - 1) * limit)).
+		Limit(int(limit)).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// FindByID returns an order of a user that has not been deleted
+func (r *PostgresOrderRepository) FindByID(ctx context.Context, userID, orderID int) (*pgmodels.Order, error) {
+	var order pgmodels.Order
+	err := r.db.WithContext(ctx).
+		Scopes(notDeletedScope, preloadOrderItems).
+		Where("user_id = ?", userID).
+		First(&order, orderID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+// FindByIdempotencyKey returns the order a user placed with an idempotency key
+func (r *PostgresOrderRepository) FindByIdempotencyKey(ctx context.Context, userID int, key string) (*pgmodels.Order, error) {
+	var order pgmodels.Order
+	err := r.db.WithContext(ctx).
+		Scopes(preloadOrderItems).
+		Where("user_id = ? AND idempotency_key = ?", userID, key).
+		First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+// FindItem returns an ordered product that has not been deleted
+func (r *PostgresOrderRepository) FindItem(ctx context.Context, itemID int) (*pgmodels.OrderedProduct, error) {
+	var item pgmodels.OrderedProduct
+	err := r.db.WithContext(ctx).Scopes(notDeletedScope).First(&item, itemID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderItemNotFound
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// HasOrderedProduct reports whether a user has an order that has not been deleted containing a product
+func (r *PostgresOrderRepository) HasOrderedProduct(ctx context.Context, userID, productID int) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&pgmodels.OrderedProduct{}).
+		Joins("JOIN orders ON orders.id = products_ordered.order_id").
+		Where("orders.user_id = ? AND orders.is_deleted = FALSE", userID).
+		Where("products_ordered.product_id = ? AND products_ordered.is_deleted = FALSE", productID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// PlaceOrder inserts an order with its products, takes the ordered quantities out of
+// inventory and removes the checked out items from the cart, all in one transaction.
+// A cart item whose quantity changed or that was removed since it was read fails the
+// checkout with ErrCartChanged, and an idempotency key already used by the user with
+// ErrDuplicateIdempotencyKey.
+func (r *PostgresOrderRepository) PlaceOrder(ctx context.Context, order *pgmodels.Order, cartItems []pgmodels.CartItem, actorID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(order).Error; err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrDuplicatedKey):
+				return ErrDuplicateIdempotencyKey
+			case errors.Is(err, gorm.ErrForeignKeyViolated):
+				return ErrProductNotOrderable
+			}
+			return err
+		}
+
+		// Take stock in product_id order so concurrent checkouts lock rows in the same order
+		items := make([]pgmodels.OrderedProduct, len(order.Items))
+		copy(items, order.Items)
+		sort.Slice(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+		for _, item := range items {
+			if err := takeStock(tx, item.ProductID, item.Quantity, actorID); err != nil {
+				return err
+			}
+		}
+
+		for _, cartItem := range cartItems {
+			result := tx.Model(&pgmodels.CartItem{}).
+				Scopes(notDeletedScope).
+				Where("id = ? AND quantity = ?", cartItem.ID, cartItem.Quantity).
+				Updates(cartDeletion(actorID))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrCartChanged
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateItemStatus moves a status column of an ordered product from one value to another.
+// It fails with ErrOrderItemChanged when the column no longer holds the expected value.
+func (r *PostgresOrderRepository) UpdateItemStatus(ctx context.Context, orderID, itemID int, column, from, to string, actorID int) error {
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.OrderedProduct{}).
+		Scopes(notDeletedScope).
+		Where("id = ? AND order_id = ?", itemID, orderID).
+		Where(column+" = ?", from).
+		Updates(map[string]interface{}{
+			column:       to,
+			"updated_at": time.Now(),
+			"updated_by": pgmodels.ActorRef(actorID),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOrderItemChanged
+	}
+	return nil
+}
+
+// preloadOrderItems loads the products of the queried orders that have not been deleted, in order
+func preloadOrderItems(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(notDeletedScope).Order("id")
+	})
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	mongomodels "petopia-be/models/mongo"
+	pgmodels "petopia-be/models/postgres"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresProductCatalogRepository implements ProductCatalogRepository using GORM
+type PostgresProductCatalogRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresProductCatalogRepository creates a new Postgres product catalog repository
+func NewPostgresProductCatalogRepository(db *gorm.DB) ProductCatalogRepository {
+	return &PostgresProductCatalogRepository{db: db}
+}
+
+// Register upserts the products row of a Mongo catalog product, keyed by its product_id,
+// so cart, inventory and order rows can reference it. The name, description and price
+// are refreshed when the row already exists.
+func (r *PostgresProductCatalogRepository) Register(ctx context.Context, product *mongomodels.ProductDetails, actorID int) error {
+	row := &pgmodels.Product{
+		ID:          product.ProductID,
+		ProductName: product.ProductName,
+		Description: product.Description,
+		Price:       product.Price,
+	}
+	row.CreatedBy = pgmodels.ActorRef(actorID)
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"product_name": row.ProductName,
+				"description":  row.Description,
+				"price":        row.Price,
+				"updated_at":   time.Now(),
+				"updated_by":   pgmodels.ActorRef(actorID),
+			}),
+		}).
+		Create(row).Error
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	pgmodels "petopia-be/models/postgres"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrSellerNotFound is returned when a seller does not exist or has been deleted
+var ErrSellerNotFound = apperrors.NotFound("seller not found")
+
+// PostgresSellerRepository implements SellerRepository using GORM
+type PostgresSellerRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresSellerRepository creates a new Postgres seller repository
+func NewPostgresSellerRepository(db *gorm.DB) SellerRepository {
+	return &PostgresSellerRepository{db: db}
+}
+
+// Create inserts a new seller
+func (r *PostgresSellerRepository) Create(ctx context.Context, seller *pgmodels.Seller) error {
+	return r.db.WithContext(ctx).Create(seller).Error
+}
+
+// FindAll returns a page of the sellers that have not been deleted, oldest first, and their total
+func (r *PostgresSellerRepository) FindAll(ctx context.Context, page, limit int64) ([]pgmodels.Seller, int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Model(&pgmodels.Seller{}).
+		Scopes(notDeletedScope).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var sellers []pgmodels.Seller
+	err = r.db.WithContext(ctx).
+		Scopes(notDeletedScope).
+		Order("id").
+		Offset(int((page - 1) * limit)).
+		Limit(int(limit)).
+		Find(&sellers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return sellers, total, nil
+}
+
+// FindByID returns a seller that has not been deleted
+func (r *PostgresSellerRepository) FindByID(ctx context.Context, id int) (*pgmodels.Seller, error) {
+	var seller pgmodels.Seller
+	err := r.db.WithContext(ctx).Scopes(notDeletedScope).First(&seller, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSellerNotFound
+		}
+		return nil, err
+	}
+	return &seller, nil
+}
+
+// Update saves the editable fields of a seller and stamps updated_at and updated_by
+func (r *PostgresSellerRepository) Update(ctx context.Context, seller *pgmodels.Seller, actorID int) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.Seller{}).
+		Scopes(notDeletedScope).
+		Where("id = ?", seller.ID).
+		Updates(map[string]interface{}{
+			"seller_name":    seller.SellerName,
+			"seller_info":    seller.SellerInfo,
+			"seller_address": seller.SellerAddress,
+			"updated_at":     now,
+			"updated_by":     pgmodels.ActorRef(actorID),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSellerNotFound
+	}
+
+	seller.UpdatedAt = &now
+	seller.UpdatedBy = pgmodels.ActorRef(actorID)
+	return nil
+}
+
+// SoftDelete marks a seller as deleted
+func (r *PostgresSellerRepository) SoftDelete(ctx context.Context, id int, actorID int) error {
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.Seller{}).
+		Scopes(notDeletedScope).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"is_deleted": true,
+			"deleted_at": time.Now(),
+			"deleted_by": pgmodels.ActorRef(actorID),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSellerNotFound
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"petopia-be/apperrors"
+	pgmodels "petopia-be/models/postgres"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Shipping errors shared with the service and controller layers
+var (
+	ErrShipmentNotFound  = apperrors.NotFound("shipment not found")
+	ErrShipmentExists    = apperrors.Conflict("a shipment already exists for this ordered product")
+	ErrShipmentDelivered = apperrors.Conflict("shipment is already delivered")
+	ErrShipmentUnlinked  = apperrors.Conflict("shipment is not linked to an ordered product")
+)
+
+// PostgresShippingRepository implements ShippingRepository using GORM
+type PostgresShippingRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresShippingRepository creates a new Postgres shipping repository
+func NewPostgresShippingRepository(db *gorm.DB) ShippingRepository {
+	return &PostgresShippingRepository{db: db}
+}
+
+// CreateShipments inserts shipments in one transaction
+func (r *PostgresShippingRepository) CreateShipments(ctx context.Context, shipments []*pgmodels.Shipment) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, shipment := range shipments {
+			if err := tx.Create(shipment).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrShipmentExists
+	}
+	return err
+}
+
+// FindByID returns a shipment that has not been deleted
+func (r *PostgresShippingRepository) FindByID(ctx context.Context, id int) (*pgmodels.Shipment, error) {
+	var shipment pgmodels.Shipment
+	err := r.db.WithContext(ctx).Scopes(notDeletedScope).First(&shipment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// ListByOrderedProducts returns the shipments that have not been deleted of the given ordered products
+func (r *PostgresShippingRepository) ListByOrderedProducts(ctx context.Context, orderedProductIDs []int) ([]pgmodels.Shipment, error) {
+	var shipments []pgmodels.Shipment
+	err := r.db.WithContext(ctx).
+		Scopes(notDeletedScope).
+		Where("ordered_product_id IN ?", orderedProductIDs).
+		Order("id").
+		Find(&shipments).Error
+	if err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+// AssignPartner sets the delivery partner of a shipment
+func (r *PostgresShippingRepository) AssignPartner(ctx context.Context, id int, partner string, actorID int) error {
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.Shipment{}).
+		Scopes(notDeletedScope).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"assigned_delivery_partner": partner,
+			"updated_at":                time.Now(),
+			"updated_by":                pgmodels.ActorRef(actorID),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrShipmentNotFound
+	}
+	return nil
+}
+
+// AppendEvent adds an event to the end of a shipment's tracking timeline. A delivered event also
+// moves the ordered product from Not Delivered to Delivered in the same transaction. No event
+// may follow a delivered one.
+func (r *PostgresShippingRepository) AppendEvent(ctx context.Context, id int, event pgmodels.TrackingEvent, actorID int) error {
+	raw, err := json.Marshal(pgmodels.TrackingEvents{event})
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var shipment pgmodels.Shipment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(notDeletedScope).
+			First(&shipment, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrShipmentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if shipment.Delivered() {
+			return ErrShipmentDelivered
+		}
+
+		now := time.Now()
+		err = tx.Model(&pgmodels.Shipment{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"location_tracking": gorm.Expr("COALESCE(location_tracking, '[]'::jsonb) || ?::jsonb", string(raw)),
+				"updated_at":        now,
+				"updated_by":        pgmodels.ActorRef(actorID),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if event.Status != pgmodels.TrackingStatusDelivered {
+			return nil
+		}
+		if shipment.OrderedProductID == nil {
+			return ErrShipmentUnlinked
+		}
+
+		result := tx.Model(&pgmodels.OrderedProduct{}).
+			Scopes(notDeletedScope).
+			Where("id = ? AND delivery_status = ?", *shipment.OrderedProductID, pgmodels.DeliveryStatusNotDelivered).
+			Updates(map[string]interface{}{
+				"delivery_status": pgmodels.DeliveryStatusDelivered,
+				"updated_at":      now,
+				"updated_by":      pgmodels.ActorRef(actorID),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderItemChanged
+		}
+		return nil
+	})
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	pgmodels "petopia-be/models/postgres"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// User errors shared with the service and controller layers
+var (
+	ErrUserNotFound    = apperrors.NotFound("user not found")
+	ErrAddressNotFound = apperrors.NotFound("address not found")
+)
+
+// PostgresUserRepository implements UserRepository using GORM
+type PostgresUserRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresUserRepository creates a new Postgres user repository
+func NewPostgresUserRepository(db *gorm.DB) UserRepository {
+	return &PostgresUserRepository{db: db}
+}
+
+// notDeletedScope restricts a query to rows that have not been soft deleted
+func notDeletedScope(db *gorm.DB) *gorm.DB {
+	return db.Where("is_deleted = ?", false)
+}
+
+// Create inserts a new user account
+func (r *PostgresUserRepository) Create(ctx context.Context, user *pgmodels.UserAccount) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+// FindByID returns a user account that has not been deleted
+func (r *PostgresUserRepository) FindByID(ctx context.Context, id int) (*pgmodels.UserAccount, error) {
+	var user pgmodels.UserAccount
+	err := r.db.WithContext(ctx).Scopes(notDeletedScope).First(&user, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Update saves the editable fields of a user account and stamps updated_at and updated_by
+func (r *PostgresUserRepository) Update(ctx context.Context, user *pgmodels.UserAccount, actorID int) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.UserAccount{}).
+		Scopes(notDeletedScope).
+		Where("id = ?", user.ID).
+		Updates(map[string]interface{}{
+			"name":       user.Name,
+			"updated_at": now,
+			"updated_by": pgmodels.ActorRef(actorID),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	user.UpdatedAt = &now
+	user.UpdatedBy = pgmodels.ActorRef(actorID)
+	return nil
+}
+
+// SoftDelete marks a user account and all of its addresses as deleted in one transaction
+func (r *PostgresUserRepository) SoftDelete(ctx context.Context, id int, actorID int) error {
+	deletion := map[string]interface{}{
+		"is_deleted": true,
+		"deleted_at": time.Now(),
+		"deleted_by": pgmodels.ActorRef(actorID),
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&pgmodels.UserAccount{}).
+			Scopes(notDeletedScope).
+			Where("id = ?", id).
+			Updates(deletion)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+
+		return tx.Model(&pgmodels.UserAddress{}).
+			Scopes(notDeletedScope).
+			Where("user_id = ?", id).
+			Updates(deletion).Error
+	})
+}
+
+// ListAddresses returns the addresses of a user that have not been deleted, oldest first
+func (r *PostgresUserRepository) ListAddresses(ctx context.Context, userID int) ([]pgmodels.UserAddress, error) {
+	var addresses []pgmodels.UserAddress
+	err := r.db.WithContext(ctx).
+		Scopes(notDeletedScope).
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&addresses).Error
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// FindAddress returns an address of a user that has not been deleted
+func (r *PostgresUserRepository) FindAddress(ctx context.Context, userID, addressID int) (*pgmodels.UserAddress, error) {
+	var address pgmodels.UserAddress
+	err := r.db.WithContext(ctx).
+		Scopes(notDeletedScope).
+		Where("user_id = ?", userID).
+		First(&address, addressID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, err
+	}
+	return &address, nil
+}
+
+// CreateAddress inserts a new address
+func (r *PostgresUserRepository) CreateAddress(ctx context.Context, address *pgmodels.UserAddress) error {
+	return r.db.WithContext(ctx).Create(address).Error
+}
+
+// UpdateAddress saves the editable fields of an address and stamps updated_at and updated_by
+func (r *PostgresUserRepository) UpdateAddress(ctx context.Context, address *pgmodels.UserAddress, actorID int) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.UserAddress{}).
+		Scopes(notDeletedScope).
+		Where("id = ? AND user_id = ?", address.ID, address.UserID).
+		Updates(map[string]interface{}{
+			"address":    address.Address,
+			"pincode":    address.Pincode,
+			"updated_at": now,
+			"updated_by": pgmodels.ActorRef(actorID),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAddressNotFound
+	}
+
+	address.UpdatedAt = &now
+	address.UpdatedBy = pgmodels.ActorRef(actorID)
+	return nil
+}
+
+// SoftDeleteAddress marks an address of a user as deleted
+func (r *PostgresUserRepository) SoftDeleteAddress(ctx context.Context, userID, addressID int, actorID int) error {
+	result := r.db.WithContext(ctx).
+		Model(&pgmodels.UserAddress{}).
+		Scopes(notDeletedScope).
+		Where("id = ? AND user_id = ?", addressID, userID).
+		Updates(map[string]interface{}{
+			"is_deleted": true,
+			"deleted_at": time.Now(),
+			"deleted_by": pgmodels.ActorRef(actorID),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAddressNotFound
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	mongomodels "petopia-be/models/mongo"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProductChange describes the fields to set on one product in a bulk write, or its soft delete.
+// Nil fields are left unchanged.
+type ProductChange struct {
+	ID           string
+	Price        *float64
+	Discount     *float64
+	Availability *bool
+	Category     *string
+	Delete       bool
+	DeletedBy    int
+}
+
+// FindByIDs returns the products among the given IDs that exist and are not deleted
+func (r *MongoProductRepository) FindByIDs(ctx context.Context, ids []string) ([]mongomodels.ProductDetails, error) {
+	objectIDs := make([]primitive.ObjectID, len(ids))
+	for i, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, ErrInvalidProductID
+		}
+		objectIDs[i] = objectID
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": objectIDs}, "is_deleted": notDeleted}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var products []mongomodels.ProductDetails
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// BulkApply writes the changes in a single unordered bulk write. The returned slice holds
+// the write error of each change, in the same order as changes, or nil when it succeeded.
+func (r *MongoProductRepository) BulkApply(ctx context.Context, changes []ProductChange) ([]error, error) {
+	itemErrors := make([]error, len(changes))
+	if len(changes) == 0 {
+		return itemErrors, nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, len(changes))
+	for i, change := range changes {
+		objectID, err := primitive.ObjectIDFromHex(change.ID)
+		if err != nil {
+			return nil, ErrInvalidProductID
+		}
+
+		set := bson.M{"updated_at": now}
+		if change.Delete {
+			set["is_deleted"] = true
+			set["deleted_at"] = now
+			if change.DeletedBy > 0 {
+				set["deleted_by"] = change.DeletedBy
+			}
+		} else {
+			if change.Price != nil {
+				set["price"] = *change.Price
+			}
+			if change.Discount != nil {
+				set["discount"] = *change.Discount
+			}
+			if change.Availability != nil {
+				set["availability"] = *change.Availability
+			}
+			if change.Category != nil {
+				set["category"] = *change.Category
+			}
+		}
+
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": objectID, "is_deleted": notDeleted}).
+			SetUpdate(bson.M{"$set": set})
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+			return nil, err
+		}
+		for _, writeErr := range bulkErr.WriteErrors {
+			itemErrors[writeErr.Index] = writeErr.WriteError
+		}
+	}
+
+	return itemErrors, nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	mongomodels "petopia-be/models/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = apperrors.BadRequest("invalid cursor")
+
+// Cursor directions
+const (
+	cursorNext = "next"
+	cursorPrev = "prev"
+)
+
+// productCursor is the decoded form of an opaque keyset pagination token.
+// It records the sort key and _id of the boundary item so the next query
+// can continue right after (or before) it regardless of inserts.
+type productCursor struct {
+	SortField string             `bson:"f"`
+	SortOrder int                `bson:"o"`
+	Value     interface{}        `bson:"v"`
+	ID        primitive.ObjectID `bson:"id"`
+	Direction string             `bson:"d"`
+}
+
+// encodeProductCursor serializes a cursor into an opaque URL-safe token
+func encodeProductCursor(cursor productCursor) (string, error) {
+	raw, err := bson.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeProductCursor parses an opaque token produced by encodeProductCursor
+func decodeProductCursor(token string) (*productCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor productCursor
+	if err := bson.Unmarshal(raw, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if _, ok := productSortFields[cursor.SortField]; !ok {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.SortOrder != 1 && cursor.SortOrder != -1 {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Direction != cursorNext && cursor.Direction != cursorPrev {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// CursorPage describes the position of a keyset-paginated result page
+type CursorPage struct {
+	Total      int64
+	NextCursor string
+	PrevCursor string
+}
+
+// FindAllByCursor returns a page of products using keyset pagination together
+// with the tokens of the next and previous pages (empty when there is none)
+func (r *MongoProductRepository) FindAllByCursor(ctx context.Context, filter dto.ProductFilter) ([]mongomodels.ProductDetails, *CursorPage, error) {
+	sort, err := buildProductSort(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	field, order := sort[0].Key, sort[0].Value.(int)
+	direction := cursorNext
+
+	query := buildProductQuery(filter)
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cursor *productCursor
+	if filter.Cursor != "" {
+		cursor, err = decodeProductCursor(filter.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// The token carries the sort it was issued for, which wins over the query parameters
+		field, order, direction = cursor.SortField, cursor.SortOrder, cursor.Direction
+		query = bson.M{"$and": []bson.M{query, keysetCondition(cursor)}}
+	}
+
+	// Walking backwards means reading in the opposite order and flipping the page afterwards
+	scanOrder := order
+	if direction == cursorPrev {
+		scanOrder = -order
+	}
+
+	// Fetch one extra item to find out whether another page follows in the scan direction
+	opts := options.Find().
+		SetSort(bson.D{{Key: field, Value: scanOrder}, {Key: "_id", Value: scanOrder}}).
+		SetLimit(filter.Limit + 1)
+
+	dbCursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer dbCursor.Close(ctx)
+
+	var docs []bson.Raw
+	if err := dbCursor.All(ctx, &docs); err != nil {
+		return nil, nil, err
+	}
+
+	hasMore := int64(len(docs)) > filter.Limit
+	if hasMore {
+		docs = docs[:filter.Limit]
+	}
+	if direction == cursorPrev {
+		for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+			docs[i], docs[j] = docs[j], docs[i]
+		}
+	}
+
+	products := make([]mongomodels.ProductDetails, len(docs))
+	for i, doc := range docs {
+		if err := bson.Unmarshal(doc, &products[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	page := &CursorPage{Total: total}
+	if len(docs) == 0 {
+		return products, page, nil
+	}
+
+	hasNext := (direction == cursorNext && hasMore) || (direction == cursorPrev && cursor != nil)
+	hasPrev := (direction == cursorPrev && hasMore) || (direction == cursorNext && cursor != nil)
+
+	if hasNext {
+		page.NextCursor, err = boundaryToken(docs[len(docs)-1], field, order, cursorNext)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if hasPrev {
+		page.PrevCursor, err = boundaryToken(docs[0], field, order, cursorPrev)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return products, page, nil
+}
+
+// keysetCondition selects the documents strictly after the cursor position in its direction
+func keysetCondition(cursor *productCursor) bson.M {
+	comparison := "$gt"
+	if (cursor.SortOrder == -1) != (cursor.Direction == cursorPrev) {
+		comparison = "$lt"
+	}
+
+	return bson.M{"$or": []bson.M{
+		{cursor.SortField: bson.M{comparison: cursor.Value}},
+		{cursor.SortField: cursor.Value, "_id": bson.M{comparison: cursor.ID}},
+	}}
+}
+
+// boundaryToken builds the cursor token pointing at the given document
+func boundaryToken(doc bson.Raw, field string, order int, direction string) (string, error) {
+	id, ok := doc.Lookup("_id").ObjectIDOK()
+	if !ok {
+		return "", ErrInvalidCursor
+	}
+
+	var value interface{}
+	if raw, err := doc.LookupErr(field); err == nil {
+		if err := raw.Unmarshal(&value); err != nil {
+			return "", err
+		}
+	}
+
+	return encodeProductCursor(productCursor{
+		SortField: field,
+		SortOrder: order,
+		Value:     value,
+		ID:        id,
+		Direction: direction,
+	})
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"petopia-be/dto"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// exportBatchSize is the number of products fetched per round trip while streaming
+const exportBatchSize = 500
+
+// ProductStream iterates over products one at a time without loading them all into memory.
+// *mongo.Cursor satisfies it.
+type ProductStream interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	Err() error
+	Close(ctx context.Context) error
+}
+
+// StreamAll opens a stream over every product matching the filter in sort order, ignoring
+// pagination. Filter and sort errors are returned before any product is read.
+func (r *MongoProductRepository) StreamAll(ctx context.Context, filter dto.ProductFilter) (ProductStream, error) {
+	sort, err := buildProductSort(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().
+		SetSort(sort).
+		SetBatchSize(exportBatchSize)
+
+	cursor, err := r.collection.Find(ctx, buildProductQuery(filter), opts)
+	if err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
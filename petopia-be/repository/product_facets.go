@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	mongomodels "petopia-be/models/mongo"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrInvalidPriceBuckets is returned when price bucket boundaries are unusable
+var ErrInvalidPriceBuckets = apperrors.BadRequest("invalid price buckets")
+
+// FacetCount is the number of matching products sharing a facet value
+type FacetCount struct {
+	Value interface{} `bson:"_id"`
+	Count int64       `bson:"count"`
+}
+
+// PriceBucketCount is the number of matching products whose price falls into a bucket.
+// Min is the inclusive lower bound; Max is the exclusive upper bound and nil for the open-ended last bucket.
+// Prices below the first boundary are not counted in any bucket.
+type PriceBucketCount struct {
+	Min   float64
+	Max   *float64
+	Count int64
+}
+
+// ProductFacets holds a page of search hits with the facet counts of the whole match set
+type ProductFacets struct {
+	Products     []mongomodels.ProductDetails
+	Total        int64
+	Categories   []FacetCount
+	Brands       []FacetCount
+	Availability []FacetCount
+	PriceBuckets []PriceBucketCount
+}
+
+// priceBucketBelowRange labels the $bucket default bucket holding prices below the first boundary
+const priceBucketBelowRange = "below_range"
+
+// FacetSearch returns a page of products matching the filter together with category, brand,
+// availability and price bucket counts, computed in a single $facet aggregation
+func (r *MongoProductRepository) FacetSearch(ctx context.Context, filter dto.ProductFilter, priceBoundaries []float64) (*ProductFacets, error) {
+	if len(priceBoundaries) == 0 || !sort.Float64sAreSorted(priceBoundaries) {
+		return nil, ErrInvalidPriceBuckets
+	}
+	for i := 1; i < len(priceBoundaries); i++ {
+		if priceBoundaries[i] == priceBoundaries[i-1] {
+			return nil, ErrInvalidPriceBuckets
+		}
+	}
+
+	sortDoc, err := buildProductSort(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// A trailing +Inf boundary makes the last configured boundary an open-ended bucket
+	boundaries := make(bson.A, 0, len(priceBoundaries)+1)
+	for _, boundary := range priceBoundaries {
+		boundaries = append(boundaries, boundary)
+	}
+	boundaries = append(boundaries, math.Inf(1))
+
+	pipeline := []bson.M{
+		{"$match": buildProductQuery(filter)},
+		{"$facet": bson.M{
+			"hits": bson.A{
+				bson.M{"$sort": sortDoc},
+				bson.M{"$skip": (filter.Page - 1) * filter.Limit},
+				bson.M{"$limit": filter.Limit},
+			},
+			"total":        bson.A{bson.M{"$count": "count"}},
+			"categories":   bson.A{bson.M{"$sortByCount": "$category"}},
+			"brands":       bson.A{bson.M{"$sortByCount": "$brand_name"}},
+			"availability": bson.A{bson.M{"$sortByCount": "$availability"}},
+			"price": bson.A{bson.M{"$bucket": bson.M{
+				"groupBy":    "$price",
+				"boundaries": boundaries,
+				"default":    priceBucketBelowRange,
+				"output":     bson.M{"count": bson.M{"$sum": 1}},
+			}}},
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Hits  []mongomodels.ProductDetails `bson:"hits"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Categories   []FacetCount `bson:"categories"`
+		Brands       []FacetCount `bson:"brands"`
+		Availability []FacetCount `bson:"availability"`
+		Price        []FacetCount `bson:"price"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	facets := &ProductFacets{
+		Products:     result.Hits,
+		Categories:   result.Categories,
+		Brands:       result.Brands,
+		Availability: result.Availability,
+		PriceBuckets: priceBucketCounts(priceBoundaries, result.Price),
+	}
+	if len(result.Total) > 0 {
+		facets.Total = result.Total[0].Count
+	}
+
+	return facets, nil
+}
+
+// priceBucketCounts turns $bucket output into one entry per configured bucket, including empty ones
+func priceBucketCounts(boundaries []float64, buckets []FacetCount) []PriceBucketCount {
+	counts := make(map[float64]int64, len(buckets))
+	for _, bucket := range buckets {
+		switch value := bucket.Value.(type) {
+		case float64:
+			counts[value] = bucket.Count
+		case int32:
+			counts[float64(value)] = bucket.Count
+		case int64:
+			counts[float64(value)] = bucket.Count
+		}
+	}
+
+	priceBuckets := make([]PriceBucketCount, len(boundaries))
+	for i, boundary := range boundaries {
+		priceBuckets[i] = PriceBucketCount{Min: boundary, Count: counts[boundary]}
+		if i+1 < len(boundaries) {
+			max := boundaries[i+1]
+			priceBuckets[i].Max = &max
+		}
+	}
+
+	return priceBuckets
+}
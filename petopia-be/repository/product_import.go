@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	mongomodels "petopia-be/models/mongo"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrProductIDDeleted is returned when an upsert targets the product_id of a soft-deleted product
+var ErrProductIDDeleted = apperrors.Conflict("product_id belongs to a deleted product")
+
+// UpsertOutcome is the result of upserting a single product in a bulk write
+type UpsertOutcome struct {
+	Created bool
+	Err     error
+}
+
+// ProductUpsert is one product of a bulk upsert. Fields names the editable fields supplied
+// for it: an existing product only has those fields replaced, while a new product is created
+// with every field.
+type ProductUpsert struct {
+	Product *mongomodels.ProductDetails
+	Fields  []string
+}
+
+// BulkUpsertByProductID creates products or replaces their supplied fields, keyed by product_id,
+// in a single unordered bulk write. Products without a product_id get the next id from the
+// sequence, and the sequence is moved past any explicit id so later creates never collide.
+// The returned outcomes are in the same order as upserts.
+func (r *MongoProductRepository) BulkUpsertByProductID(ctx context.Context, upserts []ProductUpsert) ([]UpsertOutcome, error) {
+	outcomes := make([]UpsertOutcome, len(upserts))
+	if len(upserts) == 0 {
+		return outcomes, nil
+	}
+
+	now := time.Now()
+	highestProductID := 0
+	models := make([]mongo.WriteModel, len(upserts))
+	for i, upsert := range upserts {
+		product := upsert.Product
+		if product.ProductID == 0 {
+			productID, err := r.counters.Next(ctx, ProductIDSequence)
+			if err != nil {
+				return nil, err
+			}
+			product.ProductID = int(productID)
+		} else if product.ProductID > highestProductID {
+			highestProductID = product.ProductID
+		}
+		product.UpdatedAt = now
+
+		// Supplied fields are always written; the others only fill in a newly created product
+		fields := productUpdateFields(product)
+		set := bson.M{"updated_at": now}
+		for _, field := range upsert.Fields {
+			if value, ok := fields[field]; ok {
+				set[field] = value
+			}
+		}
+		setOnInsert := bson.M{"created_at": now, "is_deleted": false}
+		for field, value := range fields {
+			if _, ok := set[field]; !ok {
+				setOnInsert[field] = value
+			}
+		}
+
+		// Soft-deleted products do not match, so the upsert hits the unique index instead
+		// of silently editing a deleted product
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"product_id": product.ProductID, "is_deleted": notDeleted}).
+			SetUpdate(bson.M{"$set": set, "$setOnInsert": setOnInsert}).
+			SetUpsert(true)
+	}
+
+	result, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+			return nil, err
+		}
+		for _, writeErr := range bulkErr.WriteErrors {
+			if mongo.IsDuplicateKeyError(writeErr.WriteError) {
+				outcomes[writeErr.Index].Err = ErrProductIDDeleted
+			} else {
+				outcomes[writeErr.Index].Err = writeErr.WriteError
+			}
+		}
+	}
+
+	if result != nil {
+		for index := range result.UpsertedIDs {
+			outcomes[index].Created = true
+		}
+	}
+
+	if err := r.counters.EnsureAtLeast(ctx, ProductIDSequence, int64(highestProductID)); err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// FindExistingProductIDs reports which of the given product_ids belong to products that are not deleted
+func (r *MongoProductRepository) FindExistingProductIDs(ctx context.Context, productIDs []int) (map[int]bool, error) {
+	existing := make(map[int]bool)
+	if len(productIDs) == 0 {
+		return existing, nil
+	}
+
+	filter := bson.M{"product_id": bson.M{"$in": productIDs}, "is_deleted": notDeleted}
+	opts := options.Find().SetProjection(bson.M{"product_id": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var product struct {
+			ProductID int `bson:"product_id"`
+		}
+		if err := cursor.Decode(&product); err != nil {
+			return nil, err
+		}
+		existing[product.ProductID] = true
+	}
+
+	return existing, cursor.Err()
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
-	"gorm.io/gorm"
 )
 
 // API route constants
@@ -18,6 +17,62 @@ const (
 	ProductByProductID = "/api/products/product/{product_id:[0-9]+}"
 	AvailableProducts  = "/api/products/available"
 	SearchProducts     = "/api/products/search"
+	RestoreProductPath = "/api/products/{id:[0-9a-fA-F]+}/restore"
+	ImportProducts     = "/api/products/import"
+	ExportProducts     = "/api/products/export"
+	BulkProducts       = "/api/products/bulk"
+
+	// Brand API paths
+	BrandsPath          = "/api/brands"
+	BrandByIDPath       = "/api/brands/{id:[0-9a-fA-F]+}"
+	BrandByBrandID      = "/api/brands/brand/{brand_id:[0-9]+}"
+	DeactivateBrandPath = "/api/brands/{id:[0-9a-fA-F]+}/deactivate"
+	SearchBrands        = "/api/brands/search"
+	BrandsByCategory    = "/api/brands/category/{category}"
+
+	// Review API paths
+	ProductReviewsPath = "/api/products/{product_id:[0-9]+}/reviews"
+	ReviewByIDPath     = "/api/products/{product_id:[0-9]+}/reviews/{review_id:[0-9a-fA-F]+}"
+	ReviewHelpfulPath  = "/api/products/{product_id:[0-9]+}/reviews/{review_id:[0-9a-fA-F]+}/helpful"
+
+	// User API paths
+	UsersPath         = "/api/users"
+	UserByIDPath      = "/api/users/{id:[0-9]+}"
+	UserAddressesPath = "/api/users/{id:[0-9]+}/addresses"
+	UserAddressPath   = "/api/users/{id:[0-9]+}/addresses/{address_id:[0-9]+}"
+	UserCardsPath     = "/api/users/{id:[0-9]+}/cards"
+	UserCardPath      = "/api/users/{id:[0-9]+}/cards/{token:card_[0-9a-f]+}"
+
+	// Cart API paths
+	CartPath      = "/api/users/{id:[0-9]+}/cart"
+	CartItemsPath = "/api/users/{id:[0-9]+}/cart/items"
+	CartItemPath  = "/api/users/{id:[0-9]+}/cart/items/{product_id:[0-9]+}"
+
+	// Checkout API path
+	CheckoutPath = "/api/checkout"
+
+	// Order API paths
+	UserOrdersPath    = "/api/users/{id:[0-9]+}/orders"
+	UserOrderPath     = "/api/users/{id:[0-9]+}/orders/{order_id:[0-9]+}"
+	UserOrderItemPath = "/api/users/{id:[0-9]+}/orders/{order_id:[0-9]+}/items/{item_id:[0-9]+}"
+
+	// Shipping API paths
+	OrderShipmentsPath   = "/api/users/{id:[0-9]+}/orders/{order_id:[0-9]+}/shipments"
+	ShipmentPath         = "/api/shipments/{id:[0-9]+}"
+	ShipmentPartnerPath  = "/api/shipments/{id:[0-9]+}/partner"
+	ShipmentTrackingPath = "/api/shipments/{id:[0-9]+}/tracking"
+
+	// Seller API paths
+	SellersPath        = "/api/sellers"
+	SellerByIDPath     = "/api/sellers/{id:[0-9]+}"
+	SellerProductsPath = "/api/sellers/{id:[0-9]+}/products"
+
+	// Inventory API paths
+	InventoryPath        = "/api/inventory/{product_id:[0-9]+}"
+	InventoryAdjustPath  = "/api/inventory/{product_id:[0-9]+}/adjust"
+	InventoryReservePath = "/api/inventory/{product_id:[0-9]+}/reserve"
+	InventoryReleasePath = "/api/inventory/{product_id:[0-9]+}/release"
+	LowStockPath         = "/api/inventory/low-stock"
 	
 	// Health check path
 	HealthPath         = "/api/health"
@@ -27,15 +82,32 @@ const (
 )
 
 // SetupV2 configures all routes for the application using clean architecture
-func SetupV2(router *mux.Router, gormDB *gorm.DB) {
-	// Create service container which will initialize all dependencies
-	serviceContainer := service.NewServiceContainer()
-	
-	// Get the product service from the container
+func SetupV2(router *mux.Router, serviceContainer *service.ServiceContainer) {
+	// Get the services from the container
 	productService := serviceContainer.ProductService
+	brandService := serviceContainer.BrandService
+	reviewService := serviceContainer.ReviewService
+	userService := serviceContainer.UserService
+	cartService := serviceContainer.CartService
+	checkoutService := serviceContainer.CheckoutService
+	inventoryService := serviceContainer.InventoryService
+	orderService := serviceContainer.OrderService
+	shippingService := serviceContainer.ShippingService
+	sellerService := serviceContainer.SellerService
+	cardService := serviceContainer.CardService
 	
 	// Initialize controllers
 	productController := controller.NewProductController(productService)
+	brandController := controller.NewBrandController(brandService)
+	reviewController := controller.NewReviewController(reviewService)
+	userController := controller.NewUserController(userService)
+	cartController := controller.NewCartController(cartService)
+	checkoutController := controller.NewCheckoutController(checkoutService)
+	inventoryController := controller.NewInventoryController(inventoryService)
+	orderController := controller.NewOrderController(orderService)
+	shippingController := controller.NewShippingController(shippingService)
+	sellerController := controller.NewSellerController(sellerService)
+	cardController := controller.NewCardController(cardService)
 	
 	// Health check
 	router.HandleFunc(HealthPath, func(w http.ResponseWriter, r *http.Request) {
@@ -46,12 +118,85 @@ func SetupV2(router *mux.Router, gormDB *gorm.DB) {
 	// Product routes with controller methods
 	router.HandleFunc(ProductsPath, productController.CreateProduct).Methods("POST")
 	router.HandleFunc(ProductsPath, productController.ListProducts).Methods("GET")
+	router.HandleFunc(ImportProducts, productController.ImportProducts).Methods("POST")
+	router.HandleFunc(ExportProducts, productController.ExportProducts).Methods("GET")
+	router.HandleFunc(BulkProducts, productController.BulkProducts).Methods("POST")
 	router.HandleFunc(AvailableProducts, productController.GetAvailableProducts).Methods("GET")
 	router.HandleFunc(SearchProducts, productController.SearchProducts).Methods("GET")
 	router.HandleFunc(ProductByIDPath, productController.GetProductByID).Methods("GET")
 	router.HandleFunc(ProductByProductID, productController.GetProductByProductID).Methods("GET")
 	router.HandleFunc(ProductByIDPath, productController.UpdateProduct).Methods("PUT")
+	router.HandleFunc(ProductByIDPath, productController.PatchProduct).Methods("PATCH")
 	router.HandleFunc(ProductByIDPath, productController.DeleteProduct).Methods("DELETE")
+	router.HandleFunc(RestoreProductPath, productController.RestoreProduct).Methods("POST")
+
+	// Brand routes
+	router.HandleFunc(BrandsPath, brandController.CreateBrand).Methods("POST")
+	router.HandleFunc(BrandsPath, brandController.ListBrands).Methods("GET")
+	router.HandleFunc(SearchBrands, brandController.SearchBrands).Methods("GET")
+	router.HandleFunc(BrandsByCategory, brandController.GetBrandsByCategory).Methods("GET")
+	router.HandleFunc(BrandByIDPath, brandController.GetBrandByID).Methods("GET")
+	router.HandleFunc(BrandByBrandID, brandController.GetBrandByBrandID).Methods("GET")
+	router.HandleFunc(BrandByIDPath, brandController.UpdateBrand).Methods("PUT")
+	router.HandleFunc(DeactivateBrandPath, brandController.DeactivateBrand).Methods("POST")
+
+	// Review routes
+	router.HandleFunc(ProductReviewsPath, reviewController.ListReviews).Methods("GET")
+	router.HandleFunc(ProductReviewsPath, reviewController.CreateReview).Methods("POST")
+	router.HandleFunc(ReviewByIDPath, reviewController.UpdateReview).Methods("PUT")
+	router.HandleFunc(ReviewByIDPath, reviewController.DeleteReview).Methods("DELETE")
+	router.HandleFunc(ReviewHelpfulPath, reviewController.MarkReviewHelpful).Methods("POST")
+
+	// User routes
+	router.HandleFunc(UsersPath, userController.CreateUser).Methods("POST")
+	router.HandleFunc(UserByIDPath, userController.GetUser).Methods("GET")
+	router.HandleFunc(UserByIDPath, userController.UpdateUser).Methods("PUT")
+	router.HandleFunc(UserByIDPath, userController.DeleteUser).Methods("DELETE")
+	router.HandleFunc(UserAddressesPath, userController.ListAddresses).Methods("GET")
+	router.HandleFunc(UserAddressesPath, userController.AddAddress).Methods("POST")
+	router.HandleFunc(UserAddressPath, userController.UpdateAddress).Methods("PUT")
+	router.HandleFunc(UserAddressPath, userController.DeleteAddress).Methods("DELETE")
+	router.HandleFunc(UserCardsPath, cardController.ListCards).Methods("GET")
+	router.HandleFunc(UserCardsPath, cardController.AddCard).Methods("POST")
+	router.HandleFunc(UserCardPath, cardController.DeleteCard).Methods("DELETE")
+
+	// Cart routes
+	router.HandleFunc(CartPath, cartController.GetCart).Methods("GET")
+	router.HandleFunc(CartPath, cartController.ClearCart).Methods("DELETE")
+	router.HandleFunc(CartItemsPath, cartController.AddItem).Methods("POST")
+	router.HandleFunc(CartItemPath, cartController.UpdateItemQuantity).Methods("PUT")
+	router.HandleFunc(CartItemPath, cartController.RemoveItem).Methods("DELETE")
+
+	// Checkout routes
+	router.HandleFunc(CheckoutPath, checkoutController.Checkout).Methods("POST")
+
+	// Order routes
+	router.HandleFunc(UserOrdersPath, orderController.ListOrders).Methods("GET")
+	router.HandleFunc(UserOrderPath, orderController.GetOrder).Methods("GET")
+	router.HandleFunc(UserOrderItemPath, orderController.UpdateItemStatus).Methods("PATCH")
+
+	// Shipping routes
+	router.HandleFunc(OrderShipmentsPath, shippingController.CreateShipments).Methods("POST")
+	router.HandleFunc(OrderShipmentsPath, shippingController.ListShipments).Methods("GET")
+	router.HandleFunc(ShipmentPath, shippingController.GetShipment).Methods("GET")
+	router.HandleFunc(ShipmentPartnerPath, shippingController.AssignPartner).Methods("PUT")
+	router.HandleFunc(ShipmentTrackingPath, shippingController.GetTracking).Methods("GET")
+	router.HandleFunc(ShipmentTrackingPath, shippingController.AddTrackingEvent).Methods("POST")
+
+	// Seller routes
+	router.HandleFunc(SellersPath, sellerController.CreateSeller).Methods("POST")
+	router.HandleFunc(SellersPath, sellerController.ListSellers).Methods("GET")
+	router.HandleFunc(SellerByIDPath, sellerController.GetSeller).Methods("GET")
+	router.HandleFunc(SellerByIDPath, sellerController.UpdateSeller).Methods("PUT")
+	router.HandleFunc(SellerByIDPath, sellerController.DeleteSeller).Methods("DELETE")
+	router.HandleFunc(SellerProductsPath, sellerController.ListSellerProducts).Methods("GET")
+
+	// Inventory routes
+	router.HandleFunc(LowStockPath, inventoryController.ListLowStock).Methods("GET")
+	router.HandleFunc(InventoryPath, inventoryController.GetInventory).Methods("GET")
+	router.HandleFunc(InventoryAdjustPath, inventoryController.AdjustStock).Methods("POST")
+	router.HandleFunc(InventoryReservePath, inventoryController.ReserveStock).Methods("POST")
+	router.HandleFunc(InventoryReleasePath, inventoryController.ReleaseStock).Methods("POST")
 
 	// Swagger
 	router.PathPrefix(SwaggerPath).Handler(httpSwagger.WrapHandler)
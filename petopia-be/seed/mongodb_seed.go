@@ -2,8 +2,10 @@ package seed
 
 import (
 	"context"
+	"errors"
 	"log"
 
+	"petopia-be/apperrors"
 	"petopia-be/dao"
 	"petopia-be/db"
 	mongo_models "petopia-be/models/mongo"
@@ -158,6 +160,13 @@ func seedProductBrands(ctx context.Context, brandDAO *dao.ProductBrandDAO) error
 	}
 
 	for _, brand := range brands {
+		// Seeding runs on every start; keep brands that are already there
+		if _, err := brandDAO.GetProductBrandByBrandID(ctx, brand.BrandID); err == nil {
+			continue
+		} else if !errors.Is(err, apperrors.ErrNotFound) {
+			log.Printf("Error checking brand %s: %v", brand.BrandName, err)
+			continue
+		}
 		if err := brandDAO.CreateProductBrand(ctx, brand); err != nil {
 			log.Printf("Error seeding brand %s: %v", brand.BrandName, err)
 		}
@@ -345,6 +354,13 @@ func seedProductDetails(ctx context.Context, productDAO *dao.ProductDetailsDAO)
 	}
 
 	for _, product := range products {
+		// Seeding runs on every start; keep products that are already there
+		if _, err := productDAO.GetProductDetailsByProductID(ctx, product.ProductID); err == nil {
+			continue
+		} else if !errors.Is(err, apperrors.ErrNotFound) {
+			log.Printf("Error checking product %s: %v", product.ProductName, err)
+			continue
+		}
 		if err := productDAO.CreateProductDetails(ctx, product); err != nil {
 			log.Printf("Error seeding product %s: %v", product.ProductName, err)
 		}
@@ -540,6 +556,15 @@ func seedCustomerReviews(ctx context.Context, reviewDAO *dao.CustomerReviewDAO)
 	}
 
 	for _, review := range reviews {
+		// Seeding runs on every start; skip reviews the customer already left for the product
+		existing, err := reviewDAO.GetReviewsByProductID(ctx, review.ProductID)
+		if err != nil {
+			log.Printf("Error checking reviews for product %d: %v", review.ProductID, err)
+			continue
+		}
+		if hasReviewFrom(existing, review.CustomerID) {
+			continue
+		}
 		if err := reviewDAO.CreateCustomerReview(ctx, review); err != nil {
 			log.Printf("Error seeding review for product %d: %v", review.ProductID, err)
 		}
@@ -548,3 +573,13 @@ func seedCustomerReviews(ctx context.Context, reviewDAO *dao.CustomerReviewDAO)
 	log.Println("Customer reviews seeded successfully!")
 	return nil
 }
+
+// hasReviewFrom reports whether one of the reviews was written by the customer
+func hasReviewFrom(reviews []*mongo_models.CustomerReview, customerID int) bool {
+	for _, review := range reviews {
+		if review.CustomerID == customerID {
+			return true
+		}
+	}
+	return false
+}
@@ -2,14 +2,14 @@ package seed
 
 import (
 	"log"
-	"fmt"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type Product struct {
-	ID          uint    `gorm:"primaryKey"`
-	Name        string
+	ID          uint   `gorm:"primaryKey"`
+	Name        string `gorm:"column:product_name"`
 	Description string
 	Price       float64
 }
@@ -35,6 +35,6 @@ func SeedDatabase(database *gorm.DB) error {
 		return result.Error
 	}
 
-	fmt.Println("Sample product data inserted successfully: %v", result.RowsAffected)
+	log.Printf("Sample product data inserted successfully: %v", result.RowsAffected)
 	return nil
 }
@@ -6,20 +6,26 @@ import (
 	"petopia-be/config"
 	"petopia-be/middleware"
 	"petopia-be/routes"
+	"petopia-be/service"
 
 	"github.com/gorilla/mux"
-	"gorm.io/gorm"
 )
 
 // StartV2 starts the HTTP server using clean architecture
-func StartV2(cfg *config.Config, db *gorm.DB) error {
+func StartV2(cfg *config.Config, services *service.ServiceContainer) error {
 	router := mux.NewRouter()
 
 	// Add CORS middleware
 	corsHandler := middleware.CORS(cfg.CORSAllowedOrigin)
 
+	// Tag every request with an ID used in logs and error responses
+	router.Use(middleware.RequestID)
+
+	// Resolve the calling user and admin access
+	router.Use(middleware.Identity(cfg.AdminAPIKey))
+
 	// Setup routes using clean architecture approach
-	routes.SetupV2(router, db)
+	routes.SetupV2(router, services)
 
 	// Start server
 	log.Printf("Starting server on port %s", cfg.Port)
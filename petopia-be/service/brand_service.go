@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	"petopia-be/dao"
+	"petopia-be/dto"
+	"petopia-be/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrBrandIDExists is returned when a brand with the same brand_id already exists
+var ErrBrandIDExists = repository.ErrBrandIDExists
+
+// BrandServiceImpl implements BrandService using the brand repository and DAO
+type BrandServiceImpl struct {
+	brandRepo repository.BrandRepository
+	brandDAO  *dao.ProductBrandDAO
+}
+
+// NewBrandService creates a new BrandService
+func NewBrandService(brandRepo repository.BrandRepository, brandDAO *dao.ProductBrandDAO) BrandService {
+	return &BrandServiceImpl{
+		brandRepo: brandRepo,
+		brandDAO:  brandDAO,
+	}
+}
+
+// CreateBrand implements BrandService
+func (s *BrandServiceImpl) CreateBrand(ctx context.Context, requestDTO dto.BrandRequestDTO) (*dto.BrandResponseDTO, error) {
+	// brand_id is the business key referenced by products, so it must be unique
+	if requestDTO.BrandID > 0 {
+		_, err := s.brandDAO.GetProductBrandByBrandID(ctx, requestDTO.BrandID)
+		if err == nil {
+			return nil, ErrBrandIDExists
+		}
+		if !errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	brand := dto.MapDTOToBrand(&requestDTO)
+
+	createdBrand, err := s.brandRepo.Create(ctx, brand)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.MapBrandToDTO(createdBrand), nil
+}
+
+// GetBrandByID implements BrandService
+func (s *BrandServiceImpl) GetBrandByID(ctx context.Context, id string) (*dto.BrandResponseDTO, error) {
+	brand, err := s.brandRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapBrandToDTO(brand), nil
+}
+
+// GetBrandByBrandID implements BrandService
+func (s *BrandServiceImpl) GetBrandByBrandID(ctx context.Context, brandID int) (*dto.BrandResponseDTO, error) {
+	brand, err := s.brandDAO.GetProductBrandByBrandID(ctx, brandID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapBrandToDTO(brand), nil
+}
+
+// GetAllBrands implements BrandService
+func (s *BrandServiceImpl) GetAllBrands(ctx context.Context, page, limit int64, activeOnly bool) (*dto.PaginatedResponse, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	filter := bson.M{}
+	if activeOnly {
+		filter["is_active"] = true
+	}
+
+	brands, err := s.brandRepo.FindAll(ctx, filter, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.brandRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	brandDTOs := dto.MapBrandsToDTOs(brands)
+	return dto.CreatePaginatedResponse(brandDTOs, total, page, limit), nil
+}
+
+// GetBrandsByCategory implements BrandService
+func (s *BrandServiceImpl) GetBrandsByCategory(ctx context.Context, category string) ([]dto.BrandResponseDTO, error) {
+	brands, err := s.brandDAO.GetBrandsByCategory(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapBrandPointersToDTOs(brands), nil
+}
+
+// UpdateBrand implements BrandService. A request without is_active keeps the brand's current state.
+func (s *BrandServiceImpl) UpdateBrand(ctx context.Context, id string, requestDTO dto.BrandRequestDTO) error {
+	if requestDTO.IsActive == nil {
+		existing, err := s.brandRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		requestDTO.IsActive = &existing.IsActive
+	}
+
+	brand := dto.MapDTOToBrand(&requestDTO)
+	return s.brandRepo.Update(ctx, id, brand)
+}
+
+// DeactivateBrand implements BrandService
+func (s *BrandServiceImpl) DeactivateBrand(ctx context.Context, id string) error {
+	// Make sure the brand exists so callers get a proper not-found error
+	if _, err := s.brandRepo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return repository.ErrInvalidBrandID
+	}
+
+	return s.brandDAO.DeactivateProductBrand(ctx, objectID)
+}
+
+// SearchBrands implements BrandService
+func (s *BrandServiceImpl) SearchBrands(ctx context.Context, term string) ([]dto.BrandResponseDTO, error) {
+	brands, err := s.brandDAO.SearchProductBrands(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapBrandPointersToDTOs(brands), nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	"petopia-be/cardvault"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"time"
+
+	pgmodels "petopia-be/models/postgres"
+)
+
+// Card limits
+const (
+	MaxCardExpiryYears  = 20
+	legacyCardBatchSize = 100
+)
+
+// ErrCardVaultUnavailable is returned by card operations when no vault key is configured
+var ErrCardVaultUnavailable = apperrors.Unavailable("card vault key is not configured")
+
+// CardServiceImpl implements CardService
+type CardServiceImpl struct {
+	cardRepo repository.CardRepository
+	userRepo repository.UserRepository
+	vault    *cardvault.Vault
+}
+
+// NewCardService creates a new CardService. A nil vault disables every card operation.
+func NewCardService(cardRepo repository.CardRepository, userRepo repository.UserRepository, vault *cardvault.Vault) CardService {
+	return &CardServiceImpl{
+		cardRepo: cardRepo,
+		userRepo: userRepo,
+		vault:    vault,
+	}
+}
+
+// AddCard implements CardService. Only the sealed number, its fingerprint and display fields
+// are stored; saving a card the user already has is a conflict.
+func (s *CardServiceImpl) AddCard(ctx context.Context, userID int, requestDTO dto.CardRequestDTO, actorID int) (*dto.CardResponseDTO, error) {
+	if s.vault == nil {
+		return nil, ErrCardVaultUnavailable
+	}
+
+	number, expiryMonth, expiryYear, err := validateCard(requestDTO, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	card, err := s.sealCard(number)
+	if err != nil {
+		return nil, err
+	}
+	card.UserID = userID
+	card.ExpiryMonth = expiryMonth
+	card.ExpiryYear = expiryYear
+	card.CreatedBy = pgmodels.ActorRef(ownerActor(actorID, userID))
+	if err := s.cardRepo.Create(ctx, card); err != nil {
+		return nil, err
+	}
+
+	return dto.MapCardToDTO(card), nil
+}
+
+// ListCards implements CardService
+func (s *CardServiceImpl) ListCards(ctx context.Context, userID int) ([]dto.CardResponseDTO, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	cards, err := s.cardRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapCardsToDTOs(cards), nil
+}
+
+// DeleteCard implements CardService
+func (s *CardServiceImpl) DeleteCard(ctx context.Context, userID int, token string, actorID int) error {
+	return s.cardRepo.SoftDelete(ctx, userID, token, actorID)
+}
+
+// SealLegacyCards implements CardService. A legacy card that duplicates a card the user
+// already has in the vault is sealed as deleted.
+func (s *CardServiceImpl) SealLegacyCards(ctx context.Context) (int, error) {
+	if s.vault == nil {
+		return 0, ErrCardVaultUnavailable
+	}
+
+	sealed := 0
+	for {
+		cards, err := s.cardRepo.ListLegacy(ctx, legacyCardBatchSize)
+		if err != nil || len(cards) == 0 {
+			return sealed, err
+		}
+
+		for _, legacy := range cards {
+			card, err := s.sealCard(cardvault.Normalize(*legacy.LegacyNumber))
+			if err != nil {
+				return sealed, err
+			}
+			card.ID = legacy.ID
+			card.Audit = legacy.Audit
+
+			err = s.cardRepo.SealLegacy(ctx, card)
+			if errors.Is(err, repository.ErrDuplicateCard) {
+				card.IsDeleted = true
+				err = s.cardRepo.SealLegacy(ctx, card)
+			}
+			if err != nil {
+				return sealed, err
+			}
+
+			sealed++
+		}
+	}
+}
+
+// sealCard seals a normalized card number and fills in its display fields
+func (s *CardServiceImpl) sealCard(number string) (*pgmodels.UserCard, error) {
+	envelope, err := s.vault.Seal(number)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := cardvault.NewToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pgmodels.UserCard{
+		Token:           token,
+		Last4:           cardvault.Last4(number),
+		Brand:           cardvault.Brand(number),
+		EncryptedNumber: envelope.Ciphertext,
+		EncryptedKey:    envelope.WrappedKey,
+		KeyID:           envelope.KeyID,
+		Fingerprint:     s.vault.Fingerprint(number),
+	}, nil
+}
+
+// validateCard normalizes a card request and checks the number and expiry. Two digit years
+// are read as 20xx. It returns a Validation error whose details map each invalid field to a
+// message; the number itself never appears in an error.
+func validateCard(requestDTO dto.CardRequestDTO, now time.Time) (string, int, int, error) {
+	number := cardvault.Normalize(string(requestDTO.CardNumber))
+	expiryMonth := requestDTO.ExpiryMonth
+	expiryYear := requestDTO.ExpiryYear
+	if expiryYear >= 0 && expiryYear < 100 {
+		expiryYear += 2000
+	}
+
+	fieldErrors := make(map[string]string)
+	if number == "" {
+		fieldErrors["card_number"] = "is required"
+	} else if !cardvault.Valid(number) {
+		fieldErrors["card_number"] = "is not a valid card number"
+	}
+
+	if expiryMonth < 1 || expiryMonth > 12 {
+		fieldErrors["expiry_month"] = "must be between 1 and 12"
+	}
+	switch {
+	case expiryYear > now.Year()+MaxCardExpiryYears:
+		fieldErrors["expiry_year"] = "is too far in the future"
+	case expiryYear < now.Year(),
+		expiryYear == now.Year() && expiryMonth >= 1 && expiryMonth < int(now.Month()):
+		fieldErrors["expiry_year"] = "card has expired"
+	}
+
+	if len(fieldErrors) > 0 {
+		return "", 0, 0, apperrors.Validation("card validation failed", fieldErrors)
+	}
+	return number, expiryMonth, expiryYear, nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+
+	mongomodels "petopia-be/models/mongo"
+	pgmodels "petopia-be/models/postgres"
+)
+
+// Quantity limits of a cart line
+const (
+	DefaultCartQuantity = 1
+	MaxCartItemQuantity = 99
+)
+
+// Cart errors
+var (
+	ErrProductUnavailable = apperrors.Conflict("product is not available")
+	ErrInvalidQuantity    = apperrors.Validation("cart validation failed", map[string]string{
+		"quantity": "must be between 1 and 99",
+	})
+)
+
+// CartServiceImpl implements CartService
+type CartServiceImpl struct {
+	cartRepo    repository.CartRepository
+	userRepo    repository.UserRepository
+	productRepo repository.ProductRepository
+	catalogRepo repository.ProductCatalogRepository
+}
+
+// NewCartService creates a new CartService
+func NewCartService(
+	cartRepo repository.CartRepository,
+	userRepo repository.UserRepository,
+	productRepo repository.ProductRepository,
+	catalogRepo repository.ProductCatalogRepository,
+) CartService {
+	return &CartServiceImpl{
+		cartRepo:    cartRepo,
+		userRepo:    userRepo,
+		productRepo: productRepo,
+		catalogRepo: catalogRepo,
+	}
+}
+
+// GetCart implements CartService
+func (s *CartServiceImpl) GetCart(ctx context.Context, userID int) (*dto.CartResponseDTO, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.priceCart(ctx, userID)
+}
+
+// AddItem implements CartService. Adding a product already in the cart increases its quantity.
+// The product is registered in the order catalog so the cart line can reference it.
+func (s *CartServiceImpl) AddItem(ctx context.Context, userID int, requestDTO dto.CartItemRequestDTO, actorID int) (*dto.CartResponseDTO, error) {
+	quantity := requestDTO.Quantity
+	if quantity == 0 {
+		quantity = DefaultCartQuantity
+	}
+	if !validCartQuantity(quantity) {
+		return nil, ErrInvalidQuantity
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	product, err := s.checkAvailable(ctx, requestDTO.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.catalogRepo.Register(ctx, product, actorID); err != nil {
+		return nil, err
+	}
+
+	item := &pgmodels.CartItem{
+		UserID:    userID,
+		ProductID: requestDTO.ProductID,
+		Quantity:  quantity,
+	}
+	item.CreatedBy = pgmodels.ActorRef(ownerActor(actorID, userID))
+	err = s.cartRepo.AddItem(ctx, item, MaxCartItemQuantity, actorID)
+	if errors.Is(err, repository.ErrCartQuantityLimit) {
+		return nil, ErrInvalidQuantity
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.priceCart(ctx, userID)
+}
+
+// UpdateItemQuantity implements CartService
+func (s *CartServiceImpl) UpdateItemQuantity(ctx context.Context, userID, productID int, requestDTO dto.CartQuantityRequestDTO, actorID int) (*dto.CartResponseDTO, error) {
+	if !validCartQuantity(requestDTO.Quantity) {
+		return nil, ErrInvalidQuantity
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.cartRepo.FindItem(ctx, userID, productID); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkAvailable(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	if err := s.cartRepo.UpdateQuantity(ctx, userID, productID, requestDTO.Quantity, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.priceCart(ctx, userID)
+}
+
+// RemoveItem implements CartService
+func (s *CartServiceImpl) RemoveItem(ctx context.Context, userID, productID int, actorID int) error {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return err
+	}
+	return s.cartRepo.RemoveItem(ctx, userID, productID, actorID)
+}
+
+// ClearCart implements CartService
+func (s *CartServiceImpl) ClearCart(ctx context.Context, userID int, actorID int) error {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return err
+	}
+	return s.cartRepo.Clear(ctx, userID, actorID)
+}
+
+// checkAvailable returns a product, rejecting products that do not exist, are deleted or are unavailable
+func (s *CartServiceImpl) checkAvailable(ctx context.Context, productID int) (*mongomodels.ProductDetails, error) {
+	product, err := s.productRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !product.Availability {
+		return nil, ErrProductUnavailable
+	}
+	return product, nil
+}
+
+// priceCart loads a user's cart and prices every line from the product catalog
+func (s *CartServiceImpl) priceCart(ctx context.Context, userID int) (*dto.CartResponseDTO, error) {
+	items, err := s.cartRepo.ListItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return priceCartItems(ctx, s.productRepo, userID, items)
+}
+
+// priceCartItems prices cart items from the product catalog, in the order given
+func priceCartItems(ctx context.Context, productRepo repository.ProductRepository, userID int, items []pgmodels.CartItem) (*dto.CartResponseDTO, error) {
+	productIDs := make([]int, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+
+	products, err := productRepo.FindByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	productsByID := make(map[int]int, len(products))
+	for i, product := range products {
+		productsByID[product.ProductID] = i
+	}
+
+	cart := &dto.CartResponseDTO{
+		UserID: userID,
+		Items:  make([]dto.CartItemResponseDTO, len(items)),
+	}
+	for i, item := range items {
+		line := dto.CartItemResponseDTO{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+
+		if index, ok := productsByID[item.ProductID]; ok {
+			product := products[index]
+			line.ProductName = product.ProductName
+			line.UnitPrice = product.Price
+			line.Discount = product.Discount
+			line.SalePrice = DiscountedPrice(product.Price, product.Discount)
+			line.Available = product.Availability
+		}
+
+		if line.Available {
+			line.LineTotal = roundCents(line.SalePrice * float64(item.Quantity))
+			cart.Total += line.LineTotal
+			cart.ItemCount += item.Quantity
+		}
+		cart.Items[i] = line
+	}
+	cart.Total = roundCents(cart.Total)
+
+	return cart, nil
+}
+
+// validCartQuantity reports whether a cart line quantity is within limits
+func validCartQuantity(quantity int) bool {
+	return quantity >= 1 && quantity <= MaxCartItemQuantity
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"strings"
+
+	pgmodels "petopia-be/models/postgres"
+)
+
+// MaxIdempotencyKeyLength is the longest Idempotency-Key accepted, matching the orders column
+const MaxIdempotencyKeyLength = 255
+
+// Checkout errors
+var (
+	ErrEmptyCart             = apperrors.Conflict("cart is empty")
+	ErrInvalidIdempotencyKey = apperrors.BadRequest("Idempotency-Key must be at most 255 characters")
+)
+
+// CheckoutServiceImpl implements CheckoutService
+type CheckoutServiceImpl struct {
+	orderRepo   repository.OrderRepository
+	cartRepo    repository.CartRepository
+	userRepo    repository.UserRepository
+	productRepo repository.ProductRepository
+	inventory   InventoryService
+}
+
+// NewCheckoutService creates a new CheckoutService
+func NewCheckoutService(
+	orderRepo repository.OrderRepository,
+	cartRepo repository.CartRepository,
+	userRepo repository.UserRepository,
+	productRepo repository.ProductRepository,
+	inventory InventoryService,
+) CheckoutService {
+	return &CheckoutServiceImpl{
+		orderRepo:   orderRepo,
+		cartRepo:    cartRepo,
+		userRepo:    userRepo,
+		productRepo: productRepo,
+		inventory:   inventory,
+	}
+}
+
+// Checkout implements CheckoutService. Each cart line is priced from the product catalog and
+// its price and discount are snapshotted on the order. Writing the order, taking the stock and
+// emptying the cart happen in one transaction, so a failed checkout leaves all three unchanged.
+func (s *CheckoutServiceImpl) Checkout(ctx context.Context, requestDTO dto.CheckoutRequestDTO, idempotencyKey string, actorID int) (*dto.OrderResponseDTO, bool, error) {
+	if err := validateCheckout(requestDTO); err != nil {
+		return nil, false, err
+	}
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+	if len(idempotencyKey) > MaxIdempotencyKeyLength {
+		return nil, false, ErrInvalidIdempotencyKey
+	}
+
+	userID := requestDTO.UserID
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, false, err
+	}
+
+	if idempotencyKey != "" {
+		existing, err := s.orderRepo.FindByIdempotencyKey(ctx, userID, idempotencyKey)
+		if err == nil {
+			return orderResponse(existing), false, nil
+		}
+		if !errors.Is(err, apperrors.ErrNotFound) {
+			return nil, false, err
+		}
+	}
+
+	address, err := s.userRepo.FindAddress(ctx, userID, requestDTO.AddressID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cartItems, err := s.cartRepo.ListItems(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(cartItems) == 0 {
+		return nil, false, ErrEmptyCart
+	}
+
+	cart, err := priceCartItems(ctx, s.productRepo, userID, cartItems)
+	if err != nil {
+		return nil, false, err
+	}
+
+	createdBy := pgmodels.ActorRef(ownerActor(actorID, userID))
+	order := &pgmodels.Order{
+		UserID:     userID,
+		Address:    address.Address + ", " + address.Pincode,
+		TotalPrice: cart.Total,
+		Items:      make([]pgmodels.OrderedProduct, len(cart.Items)),
+	}
+	order.CreatedBy = createdBy
+	if idempotencyKey != "" {
+		order.IdempotencyKey = &idempotencyKey
+	}
+
+	var unavailable []int
+	for i, line := range cart.Items {
+		if !line.Available {
+			unavailable = append(unavailable, line.ProductID)
+			continue
+		}
+		item := pgmodels.OrderedProduct{
+			ProductID:      line.ProductID,
+			ProductName:    line.ProductName,
+			Quantity:       line.Quantity,
+			Price:          line.UnitPrice,
+			Discount:       line.Discount,
+			DeliveryStatus: pgmodels.DeliveryStatusNotDelivered,
+			ReturnStatus:   pgmodels.ReturnStatusNone,
+		}
+		item.CreatedBy = createdBy
+		order.Items[i] = item
+	}
+	if len(unavailable) > 0 {
+		return nil, false, &apperrors.Error{
+			Kind:    apperrors.ErrConflict,
+			Message: "cart contains unavailable products",
+			Details: map[string][]int{"product_ids": unavailable},
+		}
+	}
+
+	err = s.orderRepo.PlaceOrder(ctx, order, cartItems, actorID)
+	if errors.Is(err, repository.ErrDuplicateIdempotencyKey) {
+		// A concurrent retry with the same key won the race; return its order
+		existing, findErr := s.orderRepo.FindByIdempotencyKey(ctx, userID, idempotencyKey)
+		if findErr != nil {
+			return nil, false, findErr
+		}
+		return orderResponse(existing), false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The order is committed; a product that sold out only has its availability refreshed late
+	productIDs := make([]int, len(order.Items))
+	for i, item := range order.Items {
+		productIDs[i] = item.ProductID
+	}
+	if err := s.inventory.SyncAvailability(ctx, productIDs); err != nil {
+		log.Printf("Error syncing availability after order %d: %v", order.ID, err)
+	}
+
+	return orderResponse(order), true, nil
+}
+
+// validateCheckout checks that a checkout request names a user and an address
+func validateCheckout(requestDTO dto.CheckoutRequestDTO) error {
+	fieldErrors := make(map[string]string)
+	if requestDTO.UserID <= 0 {
+		fieldErrors["user_id"] = "is required"
+	}
+	if requestDTO.AddressID <= 0 {
+		fieldErrors["address_id"] = "is required"
+	}
+
+	if len(fieldErrors) > 0 {
+		return apperrors.Validation("checkout validation failed", fieldErrors)
+	}
+	return nil
+}
+
+// orderResponse converts an order to its response and prices each line from its snapshot
+func orderResponse(order *pgmodels.Order) *dto.OrderResponseDTO {
+	response := dto.MapOrderToDTO(order)
+	for i := range response.Items {
+		item := &response.Items[i]
+		item.LineTotal = roundCents(DiscountedPrice(item.Price, item.Discount) * float64(item.Quantity))
+	}
+	return response
+}
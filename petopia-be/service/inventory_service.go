@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"strings"
+)
+
+// Inventory errors
+var (
+	ErrInvalidStockQuantity = apperrors.Validation("inventory validation failed", map[string]string{
+		"quantity": "must be greater than 0",
+	})
+	ErrAvailabilityTracked = apperrors.Conflict("availability of a stock-tracked product follows its inventory")
+)
+
+// InventoryServiceImpl implements InventoryService
+type InventoryServiceImpl struct {
+	inventoryRepo     repository.InventoryRepository
+	productRepo       repository.ProductRepository
+	catalogRepo       repository.ProductCatalogRepository
+	lowStockThreshold int
+}
+
+// NewInventoryService creates a new InventoryService that reports products with lowStockThreshold
+// or fewer available units as low stock
+func NewInventoryService(
+	inventoryRepo repository.InventoryRepository,
+	productRepo repository.ProductRepository,
+	catalogRepo repository.ProductCatalogRepository,
+	lowStockThreshold int,
+) InventoryService {
+	return &InventoryServiceImpl{
+		inventoryRepo:     inventoryRepo,
+		productRepo:       productRepo,
+		catalogRepo:       catalogRepo,
+		lowStockThreshold: lowStockThreshold,
+	}
+}
+
+// GetInventory implements InventoryService
+func (s *InventoryServiceImpl) GetInventory(ctx context.Context, productID int) (*dto.InventoryResponseDTO, error) {
+	if _, err := s.productRepo.FindByProductID(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.inventory(ctx, productID)
+}
+
+// AdjustStock implements InventoryService. The product is registered in the order catalog so
+// its inventory rows can reference it.
+func (s *InventoryServiceImpl) AdjustStock(ctx context.Context, productID int, requestDTO dto.InventoryAdjustRequestDTO, actorID int) (*dto.InventoryResponseDTO, error) {
+	location := strings.TrimSpace(requestDTO.InventoryLocation)
+
+	fieldErrors := make(map[string]string)
+	if requestDTO.SellerID <= 0 {
+		fieldErrors["seller_id"] = "is required"
+	}
+	if location == "" {
+		fieldErrors["inventory_location"] = "is required"
+	}
+	if requestDTO.Delta == 0 {
+		fieldErrors["delta"] = "must not be 0"
+	}
+	if len(fieldErrors) > 0 {
+		return nil, apperrors.Validation("inventory validation failed", fieldErrors)
+	}
+
+	product, err := s.productRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.catalogRepo.Register(ctx, product, actorID); err != nil {
+		return nil, err
+	}
+	if err := s.inventoryRepo.Adjust(ctx, productID, requestDTO.SellerID, location, requestDTO.Delta, actorID); err != nil {
+		return nil, err
+	}
+	return s.changed(ctx, productID)
+}
+
+// ReserveStock implements InventoryService
+func (s *InventoryServiceImpl) ReserveStock(ctx context.Context, productID int, requestDTO dto.InventoryQuantityRequestDTO, actorID int) (*dto.InventoryResponseDTO, error) {
+	if requestDTO.Quantity <= 0 {
+		return nil, ErrInvalidStockQuantity
+	}
+
+	if _, err := s.productRepo.FindByProductID(ctx, productID); err != nil {
+		return nil, err
+	}
+	if err := s.inventoryRepo.Reserve(ctx, productID, requestDTO.Quantity, actorID); err != nil {
+		return nil, err
+	}
+	return s.changed(ctx, productID)
+}
+
+// ReleaseStock implements InventoryService
+func (s *InventoryServiceImpl) ReleaseStock(ctx context.Context, productID int, requestDTO dto.InventoryQuantityRequestDTO, actorID int) (*dto.InventoryResponseDTO, error) {
+	if requestDTO.Quantity <= 0 {
+		return nil, ErrInvalidStockQuantity
+	}
+
+	if _, err := s.productRepo.FindByProductID(ctx, productID); err != nil {
+		return nil, err
+	}
+	if err := s.inventoryRepo.Release(ctx, productID, requestDTO.Quantity, actorID); err != nil {
+		return nil, err
+	}
+	return s.changed(ctx, productID)
+}
+
+// ListLowStock implements InventoryService
+func (s *InventoryServiceImpl) ListLowStock(ctx context.Context, threshold *int) (*dto.LowStockResponseDTO, error) {
+	limit := s.lowStockThreshold
+	if threshold != nil {
+		if *threshold < 0 {
+			return nil, apperrors.BadRequest("threshold must not be negative")
+		}
+		limit = *threshold
+	}
+
+	levels, err := s.inventoryRepo.ListLowStock(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.LowStockResponseDTO{
+		Threshold: limit,
+		Products:  make([]dto.InventoryResponseDTO, len(levels)),
+	}
+	for i, level := range levels {
+		response.Products[i] = dto.InventoryResponseDTO{
+			ProductID:         level.ProductID,
+			Quantity:          level.Quantity,
+			Reserved:          level.Reserved,
+			Available:         level.Available(),
+			LowStock:          true,
+			LowStockThreshold: limit,
+		}
+	}
+	return response, nil
+}
+
+// SyncAvailability implements InventoryService
+func (s *InventoryServiceImpl) SyncAvailability(ctx context.Context, productIDs []int) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	availability, err := stockAvailability(ctx, s.inventoryRepo, productIDs)
+	if err != nil {
+		return err
+	}
+
+	var inStock, outOfStock []int
+	for productID, available := range availability {
+		if available {
+			inStock = append(inStock, productID)
+		} else {
+			outOfStock = append(outOfStock, productID)
+		}
+	}
+
+	if len(inStock) > 0 {
+		if err := s.productRepo.SetAvailability(ctx, inStock, true); err != nil {
+			return err
+		}
+	}
+	if len(outOfStock) > 0 {
+		if err := s.productRepo.SetAvailability(ctx, outOfStock, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stockAvailability derives the availability of the stock-tracked products among productIDs:
+// a product with inventory rows is available while it has sellable units. Products without
+// rows are untracked and left out of the result; their availability is set by hand and
+// checkout sells them without drawing stock.
+func stockAvailability(ctx context.Context, inventoryRepo repository.InventoryRepository, productIDs []int) (map[int]bool, error) {
+	availability := make(map[int]bool)
+	if len(productIDs) == 0 {
+		return availability, nil
+	}
+
+	levels, err := inventoryRepo.StockLevels(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	for productID, level := range levels {
+		availability[productID] = level.Available() > 0
+	}
+	return availability, nil
+}
+
+// changed derives a product's availability after a stock change and returns its inventory
+func (s *InventoryServiceImpl) changed(ctx context.Context, productID int) (*dto.InventoryResponseDTO, error) {
+	if err := s.SyncAvailability(ctx, []int{productID}); err != nil {
+		return nil, err
+	}
+	return s.inventory(ctx, productID)
+}
+
+// inventory loads a product's stock rows and flags low stock
+func (s *InventoryServiceImpl) inventory(ctx context.Context, productID int) (*dto.InventoryResponseDTO, error) {
+	items, err := s.inventoryRepo.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := dto.MapInventoryItemsToDTO(productID, items)
+	inventory.LowStockThreshold = s.lowStockThreshold
+	inventory.LowStock = inventory.Available <= s.lowStockThreshold
+	return inventory, nil
+}
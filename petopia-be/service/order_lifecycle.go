@@ -0,0 +1,82 @@
+package service
+
+import (
+	"petopia-be/apperrors"
+	"strings"
+
+	pgmodels "petopia-be/models/postgres"
+)
+
+// Status columns of an ordered product
+const (
+	deliveryStatusField = "delivery_status"
+	returnStatusField   = "return_status"
+)
+
+// orderStatusMachine lists the statuses a status column may take and the moves allowed from each
+type orderStatusMachine struct {
+	field       string
+	statuses    []string
+	transitions map[string][]string
+}
+
+// Delivery moves from Not Delivered to Delivered; returns from None to Return Requested to Returned
+var (
+	deliveryStatusMachine = orderStatusMachine{
+		field:    deliveryStatusField,
+		statuses: []string{pgmodels.DeliveryStatusNotDelivered, pgmodels.DeliveryStatusDelivered},
+		transitions: map[string][]string{
+			pgmodels.DeliveryStatusNotDelivered: {pgmodels.DeliveryStatusDelivered},
+		},
+	}
+	returnStatusMachine = orderStatusMachine{
+		field:    returnStatusField,
+		statuses: []string{pgmodels.ReturnStatusNone, pgmodels.ReturnStatusRequested, pgmodels.ReturnStatusReturned},
+		transitions: map[string][]string{
+			pgmodels.ReturnStatusNone:      {pgmodels.ReturnStatusRequested},
+			pgmodels.ReturnStatusRequested: {pgmodels.ReturnStatusReturned},
+		},
+	}
+)
+
+// ErrReturnBeforeDelivery is returned when a return is requested for a product not yet delivered
+var ErrReturnBeforeDelivery = apperrors.Conflict("a return can only be requested once the product is delivered")
+
+// check returns a Validation error for an unknown status and a Conflict error, whose details
+// list the allowed moves, for a move the machine does not allow
+func (m orderStatusMachine) check(from, to string) error {
+	if !containsStatus(m.statuses, to) {
+		return apperrors.Validation("order status validation failed", map[string]string{
+			m.field: "must be one of " + strings.Join(m.statuses, ", "),
+		})
+	}
+
+	allowed := m.transitions[from]
+	if containsStatus(allowed, to) {
+		return nil
+	}
+
+	if allowed == nil {
+		allowed = []string{}
+	}
+	return &apperrors.Error{
+		Kind:    apperrors.ErrConflict,
+		Message: "cannot change " + m.field + " from " + from + " to " + to,
+		Details: map[string]interface{}{
+			"field":   m.field,
+			"from":    from,
+			"to":      to,
+			"allowed": allowed,
+		},
+	}
+}
+
+// containsStatus reports whether status is one of statuses
+func containsStatus(statuses []string, status string) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"petopia-be/apperrors"
+	"reflect"
+	"testing"
+
+	pgmodels "petopia-be/models/postgres"
+)
+
+func TestOrderStatusMachineCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		machine     orderStatusMachine
+		from, to    string
+		wantKind    error
+		wantAllowed []string
+	}{
+		{
+			name:    "deliver",
+			machine: deliveryStatusMachine,
+			from:    pgmodels.DeliveryStatusNotDelivered,
+			to:      pgmodels.DeliveryStatusDelivered,
+		},
+		{
+			name:        "undeliver",
+			machine:     deliveryStatusMachine,
+			from:        pgmodels.DeliveryStatusDelivered,
+			to:          pgmodels.DeliveryStatusNotDelivered,
+			wantKind:    apperrors.ErrConflict,
+			wantAllowed: []string{},
+		},
+		{
+			name:        "deliver twice",
+			machine:     deliveryStatusMachine,
+			from:        pgmodels.DeliveryStatusDelivered,
+			to:          pgmodels.DeliveryStatusDelivered,
+			wantKind:    apperrors.ErrConflict,
+			wantAllowed: []string{},
+		},
+		{
+			name:     "unknown delivery status",
+			machine:  deliveryStatusMachine,
+			from:     pgmodels.DeliveryStatusNotDelivered,
+			to:       "Shipped",
+			wantKind: apperrors.ErrValidation,
+		},
+		{
+			name:    "request return",
+			machine: returnStatusMachine,
+			from:    pgmodels.ReturnStatusNone,
+			to:      pgmodels.ReturnStatusRequested,
+		},
+		{
+			name:    "complete return",
+			machine: returnStatusMachine,
+			from:    pgmodels.ReturnStatusRequested,
+			to:      pgmodels.ReturnStatusReturned,
+		},
+		{
+			name:        "return without request",
+			machine:     returnStatusMachine,
+			from:        pgmodels.ReturnStatusNone,
+			to:          pgmodels.ReturnStatusReturned,
+			wantKind:    apperrors.ErrConflict,
+			wantAllowed: []string{pgmodels.ReturnStatusRequested},
+		},
+		{
+			name:        "cancel request",
+			machine:     returnStatusMachine,
+			from:        pgmodels.ReturnStatusRequested,
+			to:          pgmodels.ReturnStatusNone,
+			wantKind:    apperrors.ErrConflict,
+			wantAllowed: []string{pgmodels.ReturnStatusReturned},
+		},
+		{
+			name:     "unknown return status",
+			machine:  returnStatusMachine,
+			from:     pgmodels.ReturnStatusNone,
+			to:       "returned",
+			wantKind: apperrors.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.machine.check(tt.from, tt.to)
+			if tt.wantKind == nil {
+				if err != nil {
+					t.Fatalf("check(%q, %q) error = %v, want nil", tt.from, tt.to, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantKind) {
+				t.Fatalf("check(%q, %q) error = %v, want kind %v", tt.from, tt.to, err, tt.wantKind)
+			}
+			if tt.wantAllowed == nil {
+				return
+			}
+
+			details, ok := apperrors.DetailsOf(err).(map[string]interface{})
+			if !ok {
+				t.Fatalf("check(%q, %q) details = %#v, want a map", tt.from, tt.to, apperrors.DetailsOf(err))
+			}
+			if got := details["allowed"]; !reflect.DeepEqual(got, tt.wantAllowed) {
+				t.Errorf("check(%q, %q) allowed = %#v, want %#v", tt.from, tt.to, got, tt.wantAllowed)
+			}
+			if got := details["field"]; got != tt.machine.field {
+				t.Errorf("check(%q, %q) field = %v, want %q", tt.from, tt.to, got, tt.machine.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+
+	pgmodels "petopia-be/models/postgres"
+)
+
+// Page sizes of order history
+const (
+	DefaultOrderPageSize = 10
+	MaxOrderPageSize     = 100
+)
+
+// ErrInvalidStatusRequest is returned unless exactly one status is given
+var ErrInvalidStatusRequest = apperrors.Validation("order status validation failed", map[string]string{
+	"status": "exactly one of delivery_status and return_status is required",
+})
+
+// OrderServiceImpl implements OrderService
+type OrderServiceImpl struct {
+	orderRepo repository.OrderRepository
+	userRepo  repository.UserRepository
+}
+
+// NewOrderService creates a new OrderService
+func NewOrderService(orderRepo repository.OrderRepository, userRepo repository.UserRepository) OrderService {
+	return &OrderServiceImpl{
+		orderRepo: orderRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// ListOrders implements OrderService
+func (s *OrderServiceImpl) ListOrders(ctx context.Context, userID int, page, limit int64) (*dto.PaginatedResponse, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultOrderPageSize
+	}
+	if limit > MaxOrderPageSize {
+		limit = MaxOrderPageSize
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	orders, total, err := s.orderRepo.ListByUser(ctx, userID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	orderDTOs := make([]dto.OrderResponseDTO, len(orders))
+	for i := range orders {
+		orderDTOs[i] = *orderResponse(&orders[i])
+	}
+	return dto.CreatePaginatedResponse(orderDTOs, total, page, limit), nil
+}
+
+// GetOrder implements OrderService
+func (s *OrderServiceImpl) GetOrder(ctx context.Context, userID, orderID int) (*dto.OrderResponseDTO, error) {
+	order, err := s.orderRepo.FindByID(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return orderResponse(order), nil
+}
+
+// UpdateItemStatus implements OrderService
+func (s *OrderServiceImpl) UpdateItemStatus(ctx context.Context, userID, orderID, itemID int, requestDTO dto.OrderItemStatusRequestDTO, actorID int) (*dto.OrderResponseDTO, error) {
+	hasDelivery := requestDTO.DeliveryStatus != ""
+	hasReturn := requestDTO.ReturnStatus != ""
+	if hasDelivery == hasReturn {
+		return nil, ErrInvalidStatusRequest
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	item := findOrderItem(order, itemID)
+	if item == nil {
+		return nil, repository.ErrOrderItemNotFound
+	}
+
+	machine, from, to := deliveryStatusMachine, item.DeliveryStatus, requestDTO.DeliveryStatus
+	if hasReturn {
+		machine, from, to = returnStatusMachine, item.ReturnStatus, requestDTO.ReturnStatus
+	}
+	if err := machine.check(from, to); err != nil {
+		return nil, err
+	}
+	if to == pgmodels.ReturnStatusRequested && item.DeliveryStatus != pgmodels.DeliveryStatusDelivered {
+		return nil, ErrReturnBeforeDelivery
+	}
+
+	if err := s.orderRepo.UpdateItemStatus(ctx, orderID, itemID, machine.field, from, to, actorID); err != nil {
+		return nil, err
+	}
+
+	order, err = s.orderRepo.FindByID(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return orderResponse(order), nil
+}
+
+// findOrderItem returns the ordered product with the given ID, or nil when the order has none
+func findOrderItem(order *pgmodels.Order, itemID int) *pgmodels.OrderedProduct {
+	for i := range order.Items {
+		if order.Items[i].ID == itemID {
+			return &order.Items[i]
+		}
+	}
+	return nil
+}
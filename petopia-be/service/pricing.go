@@ -0,0 +1,13 @@
+package service
+
+import "math"
+
+// DiscountedPrice applies a percentage discount to a price, rounded to cents
+func DiscountedPrice(price, discountPercent float64) float64 {
+	return math.Round(price*(100-discountPercent)) / 100
+}
+
+// roundCents rounds an amount to two decimals
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,28 @@
+package service
+
+import "testing"
+
+func TestDiscountedPrice(t *testing.T) {
+	tests := []struct {
+		name            string
+		price           float64
+		discountPercent float64
+		want            float64
+	}{
+		{"no discount", 19.99, 0, 19.99},
+		{"whole discount", 19.99, 100, 0},
+		{"half off", 50, 50, 25},
+		{"rounds half cents up", 10.05, 50, 5.03},
+		{"rounds down", 19.99, 15, 16.99},
+		{"fractional percent", 100, 12.5, 87.5},
+		{"free product", 0, 20, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiscountedPrice(tt.price, tt.discountPercent); got != tt.want {
+				t.Errorf("DiscountedPrice(%v, %v) = %v, want %v", tt.price, tt.discountPercent, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"log"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxBulkProducts caps the number of distinct products a bulk request may touch
+const MaxBulkProducts = 1000
+
+// Bulk request errors that reject the whole batch
+var (
+	ErrEmptyBulkRequest = apperrors.BadRequest("bulk request has no operations")
+	ErrBulkTooLarge     = apperrors.BadRequest("bulk request targets more than 1000 products")
+)
+
+// bulkTarget collects every action of a bulk request that applies to one product
+type bulkTarget struct {
+	change  repository.ProductChange
+	actions []string
+}
+
+// BulkUpdateProducts implements ProductService. Operations select products by ID list or by
+// filter; all actions on the same product are merged into a single write, with later operations
+// winning and delete taking precedence over field changes. The writes run as one bulk write and
+// every product gets its own result. Setting the availability of a stock-tracked product fails
+// that product, as it follows the inventory. A dry run resolves and reports without writing.
+func (s *ProductServiceV2) BulkUpdateProducts(ctx context.Context, request dto.BulkProductRequestDTO, actorID int) (*dto.BulkProductResponseDTO, error) {
+	if len(request.Operations) == 0 {
+		return nil, ErrEmptyBulkRequest
+	}
+	if err := validateBulkOperations(request.Operations); err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]*bulkTarget)
+	var order []string
+	for _, operation := range request.Operations {
+		ids, err := s.resolveBulkIDs(ctx, operation)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			target, ok := targets[id]
+			if !ok {
+				target = &bulkTarget{change: repository.ProductChange{ID: id, DeletedBy: actorID}}
+				targets[id] = target
+				order = append(order, id)
+			}
+			applyBulkAction(&target.change, operation)
+			target.actions = append(target.actions, operation.Action)
+		}
+		if len(order) > MaxBulkProducts {
+			return nil, ErrBulkTooLarge
+		}
+	}
+
+	response := &dto.BulkProductResponseDTO{
+		DryRun:  request.DryRun,
+		Total:   len(order),
+		Results: make([]dto.BulkItemResultDTO, len(order)),
+	}
+
+	var validIDs []string
+	for i, id := range order {
+		response.Results[i] = dto.BulkItemResultDTO{ID: id, Actions: targets[id].actions}
+		if primitive.IsValidObjectID(id) {
+			validIDs = append(validIDs, id)
+		}
+	}
+
+	existing := make(map[string]int)
+	if len(validIDs) > 0 {
+		products, err := s.productRepo.FindByIDs(ctx, validIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range products {
+			existing[product.ID.Hex()] = product.ProductID
+		}
+	}
+
+	var availabilityProductIDs []int
+	for _, id := range order {
+		if productID, found := existing[id]; found && targets[id].change.Availability != nil {
+			availabilityProductIDs = append(availabilityProductIDs, productID)
+		}
+	}
+	tracked, err := stockAvailability(ctx, s.inventoryRepo, availabilityProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []repository.ProductChange
+	var changeResults []int
+	for i, id := range order {
+		result := &response.Results[i]
+		productID, found := existing[id]
+		_, stockTracked := tracked[productID]
+		switch {
+		case !primitive.IsValidObjectID(id):
+			failBulkItem(result, repository.ErrInvalidProductID)
+		case !found:
+			failBulkItem(result, repository.ErrProductNotFound)
+		case stockTracked && targets[id].change.Availability != nil && !targets[id].change.Delete:
+			result.ProductID = productID
+			failBulkItem(result, ErrAvailabilityTracked)
+		default:
+			result.ProductID = productID
+			changes = append(changes, targets[id].change)
+			changeResults = append(changeResults, i)
+		}
+	}
+
+	if !request.DryRun {
+		itemErrors, err := s.productRepo.BulkApply(ctx, changes)
+		if err != nil {
+			return nil, err
+		}
+		for i, itemErr := range itemErrors {
+			if itemErr != nil {
+				failBulkItem(&response.Results[changeResults[i]], itemErr)
+			}
+		}
+	}
+
+	for i, change := range changes {
+		result := &response.Results[changeResults[i]]
+		if result.Status != "" {
+			continue
+		}
+		if change.Delete {
+			result.Status = dto.BulkStatusDeleted
+		} else {
+			result.Status = dto.BulkStatusUpdated
+		}
+	}
+
+	for _, result := range response.Results {
+		if result.Status == dto.BulkStatusFailed {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+
+	return response, nil
+}
+
+// resolveBulkIDs returns the IDs selected by an operation's ID list or filter
+func (s *ProductServiceV2) resolveBulkIDs(ctx context.Context, operation dto.BulkOperationDTO) ([]string, error) {
+	if operation.Filter == nil {
+		return operation.IDs, nil
+	}
+
+	// Fetch one product more than allowed to detect filters that match too many
+	filter := operation.Filter.ToProductFilter()
+	filter.Page = 1
+	filter.Limit = MaxBulkProducts + 1
+
+	products, _, err := s.productRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) > MaxBulkProducts {
+		return nil, ErrBulkTooLarge
+	}
+
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.ID.Hex()
+	}
+	return ids, nil
+}
+
+// validateBulkOperations checks every operation's action, value and target selection.
+// It returns a Validation error whose details map each invalid operation field to a message.
+func validateBulkOperations(operations []dto.BulkOperationDTO) error {
+	fieldErrors := make(map[string]string)
+
+	for i, operation := range operations {
+		field := "operations[" + strconv.Itoa(i) + "]"
+
+		switch {
+		case len(operation.IDs) > 0 && operation.Filter != nil:
+			fieldErrors[field] = "must select products by ids or filter, not both"
+		case len(operation.IDs) == 0 && operation.Filter == nil:
+			fieldErrors[field] = "must select products by ids or filter"
+		case operation.Filter != nil && operation.Filter.IsEmpty():
+			fieldErrors[field+".filter"] = "must have at least one criterion"
+		}
+
+		switch operation.Action {
+		case dto.BulkActionSetPrice:
+			if price, ok := operation.Value.(float64); !ok || price <= 0 {
+				fieldErrors[field+".value"] = "must be a price greater than 0"
+			}
+		case dto.BulkActionSetDiscount:
+			if discount, ok := operation.Value.(float64); !ok || discount < 0 || discount > MaxDiscountPercent {
+				fieldErrors[field+".value"] = "must be a discount between 0 and 100"
+			}
+		case dto.BulkActionSetAvailability:
+			if _, ok := operation.Value.(bool); !ok {
+				fieldErrors[field+".value"] = "must be true or false"
+			}
+		case dto.BulkActionSetCategory:
+			if category, ok := operation.Value.(string); !ok || !KnownProductCategories[category] {
+				fieldErrors[field+".value"] = "must be a known category"
+			}
+		case dto.BulkActionDelete:
+		default:
+			fieldErrors[field+".action"] = "is not a supported action"
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return apperrors.Validation("bulk request validation failed", fieldErrors)
+	}
+	return nil
+}
+
+// applyBulkAction records a validated operation's action on a product change
+func applyBulkAction(change *repository.ProductChange, operation dto.BulkOperationDTO) {
+	switch operation.Action {
+	case dto.BulkActionSetPrice:
+		price := operation.Value.(float64)
+		change.Price = &price
+	case dto.BulkActionSetDiscount:
+		discount := operation.Value.(float64)
+		change.Discount = &discount
+	case dto.BulkActionSetAvailability:
+		availability := operation.Value.(bool)
+		change.Availability = &availability
+	case dto.BulkActionSetCategory:
+		category := operation.Value.(string)
+		change.Category = &category
+	case dto.BulkActionDelete:
+		change.Delete = true
+	}
+}
+
+// failBulkItem marks a product result as failed, hiding infrastructure errors
+func failBulkItem(result *dto.BulkItemResultDTO, err error) {
+	result.Status = dto.BulkStatusFailed
+	if apperrors.Code(err) == apperrors.CodeInternal {
+		log.Printf("Error applying bulk change to product %s: %v", result.ID, err)
+		result.Error = "product could not be written"
+		return
+	}
+	result.Error = err.Error()
+}
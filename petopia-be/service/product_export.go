@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"strconv"
+	"strings"
+
+	mongomodels "petopia-be/models/mongo"
+)
+
+// Google Merchant feed constants
+const (
+	merchantNamespace      = "http://base.google.com/ns/1.0"
+	merchantInStock        = "in_stock"
+	merchantOutOfStock     = "out_of_stock"
+	merchantConditionNew   = "new"
+	merchantFeedTitle      = "Petopia product catalog"
+	merchantFeedDesc       = "All Petopia products"
+	exportCSVFlushInterval = 100
+)
+
+// ErrUnsupportedExportFormat is returned for an export format other than csv, ndjson or xml
+var ErrUnsupportedExportFormat = apperrors.BadRequest("unsupported export format, expected csv, ndjson or xml")
+
+// ProductFeedConfig holds the storefront settings used to build product feed links and prices
+type ProductFeedConfig struct {
+	StorefrontURL string
+	Currency      string
+}
+
+// ProductExport is an open product export that streams its rows once written
+type ProductExport struct {
+	Format string
+	stream repository.ProductStream
+	feed   ProductFeedConfig
+}
+
+// merchantItem is a product entry of a Google Merchant RSS feed
+type merchantItem struct {
+	XMLName      xml.Name `xml:"item"`
+	ID           int      `xml:"g:id"`
+	Title        string   `xml:"g:title"`
+	Description  string   `xml:"g:description"`
+	Link         string   `xml:"g:link"`
+	Price        string   `xml:"g:price"`
+	SalePrice    string   `xml:"g:sale_price,omitempty"`
+	Availability string   `xml:"g:availability"`
+	Brand        string   `xml:"g:brand,omitempty"`
+	ProductType  string   `xml:"g:product_type,omitempty"`
+	Condition    string   `xml:"g:condition"`
+}
+
+// ExportProducts implements ProductService. It opens a stream over every product matching
+// the filter so that filter errors surface before the caller starts writing a response.
+func (s *ProductServiceV2) ExportProducts(ctx context.Context, filter dto.ProductFilter, format string) (*ProductExport, error) {
+	switch format {
+	case dto.FormatCSV, dto.FormatNDJSON, dto.FormatXML:
+	default:
+		return nil, ErrUnsupportedExportFormat
+	}
+
+	stream, err := s.productRepo.StreamAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProductExport{
+		Format: format,
+		stream: stream,
+		feed:   s.feedConfig,
+	}, nil
+}
+
+// ContentType returns the media type of the export
+func (e *ProductExport) ContentType() string {
+	switch e.Format {
+	case dto.FormatCSV:
+		return "text/csv; charset=utf-8"
+	case dto.FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/xml; charset=utf-8"
+	}
+}
+
+// Stream writes every product of the export to w one row at a time and closes the stream.
+// CSV and NDJSON rows use the import layout so an export can be imported again.
+func (e *ProductExport) Stream(ctx context.Context, w io.Writer) error {
+	defer e.stream.Close(ctx)
+
+	switch e.Format {
+	case dto.FormatCSV:
+		return e.writeCSV(ctx, w)
+	case dto.FormatNDJSON:
+		return e.writeNDJSON(ctx, w)
+	default:
+		return e.writeMerchantFeed(ctx, w)
+	}
+}
+
+// each decodes the streamed products one by one and passes them to fn
+func (e *ProductExport) each(ctx context.Context, fn func(product *mongomodels.ProductDetails) error) error {
+	for e.stream.Next(ctx) {
+		var product mongomodels.ProductDetails
+		if err := e.stream.Decode(&product); err != nil {
+			return err
+		}
+		if err := fn(&product); err != nil {
+			return err
+		}
+	}
+	return e.stream.Err()
+}
+
+// writeCSV writes a header row followed by one row per product
+func (e *ProductExport) writeCSV(ctx context.Context, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(dto.ProductCSVColumns); err != nil {
+		return err
+	}
+
+	rows := 0
+	err := e.each(ctx, func(product *mongomodels.ProductDetails) error {
+		record, err := productCSVRecord(product)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+
+		// Flush regularly so rows reach the client while the cursor is still being read
+		rows++
+		if rows%exportCSVFlushInterval == 0 {
+			writer.Flush()
+			return writer.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// productCSVRecord converts a product to a record in the order of ProductCSVColumns
+func productCSVRecord(product *mongomodels.ProductDetails) ([]string, error) {
+	dimensions := ""
+	if len(product.ItemDimensions) > 0 {
+		raw, err := json.Marshal(product.ItemDimensions)
+		if err != nil {
+			return nil, err
+		}
+		dimensions = string(raw)
+	}
+
+	return []string{
+		strconv.Itoa(product.ProductID),
+		product.ProductName,
+		product.Description,
+		strconv.Itoa(product.BrandID),
+		product.BrandName,
+		strconv.Itoa(product.SellerID),
+		product.Category,
+		dimensions,
+		strconv.FormatFloat(product.Price, 'f', -1, 64),
+		strconv.FormatFloat(product.Discount, 'f', -1, 64),
+		strconv.FormatBool(product.Availability),
+	}, nil
+}
+
+// writeNDJSON writes one JSON object per line
+func (e *ProductExport) writeNDJSON(ctx context.Context, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	return e.each(ctx, func(product *mongomodels.ProductDetails) error {
+		return encoder.Encode(dto.ProductImportRowDTO{
+			ProductID:         product.ProductID,
+			ProductRequestDTO: *dto.MapProductToRequestDTO(product),
+		})
+	})
+}
+
+// writeMerchantFeed writes a Google Merchant RSS 2.0 feed with one item per product
+func (e *ProductExport) writeMerchantFeed(ctx context.Context, w io.Writer) error {
+	storefrontURL := strings.TrimRight(e.feed.StorefrontURL, "/")
+
+	header := xml.Header +
+		`<rss version="2.0" xmlns:g="` + merchantNamespace + `">` + "\n<channel>\n" +
+		"<title>" + merchantFeedTitle + "</title>\n" +
+		"<link>" + xmlEscape(storefrontURL) + "</link>\n" +
+		"<description>" + merchantFeedDesc + "</description>\n"
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	err := e.each(ctx, func(product *mongomodels.ProductDetails) error {
+		item := merchantItem{
+			ID:           product.ProductID,
+			Title:        product.ProductName,
+			Description:  product.Description,
+			Link:         storefrontURL + "/products/" + strconv.Itoa(product.ProductID),
+			Price:        e.merchantPrice(product.Price),
+			Availability: merchantOutOfStock,
+			Brand:        product.BrandName,
+			ProductType:  product.Category,
+			Condition:    merchantConditionNew,
+		}
+		if product.Availability {
+			item.Availability = merchantInStock
+		}
+		if product.Discount > 0 {
+			item.SalePrice = e.merchantPrice(DiscountedPrice(product.Price, product.Discount))
+		}
+
+		// Encode flushes after every item, so the feed streams as it is read
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "</channel>\n</rss>\n")
+	return err
+}
+
+// merchantPrice formats an amount the way Merchant feeds expect, e.g. "12.99 USD"
+func (e *ProductExport) merchantPrice(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64) + " " + e.feed.Currency
+}
+
+// xmlEscape escapes text for use in XML character data
+func xmlEscape(text string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
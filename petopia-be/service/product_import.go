@@ -0,0 +1,478 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"strconv"
+	"strings"
+)
+
+// Limits enforced on product imports
+const (
+	MaxImportRows      = 5000
+	MaxImportLineBytes = 1 << 20
+)
+
+// Import errors that reject the whole file
+var (
+	ErrUnsupportedImportFormat = apperrors.BadRequest("unsupported import format, expected csv or ndjson")
+	ErrEmptyImport             = apperrors.BadRequest("import contains no product rows")
+	ErrTooManyImportRows       = apperrors.BadRequest("import exceeds the maximum of 5000 rows")
+	ErrInvalidImportHeader     = apperrors.BadRequest("CSV header must include the product_name and price columns")
+)
+
+// importLine is a parsed line of an import file, or the reason it could not be parsed.
+// fields names the product fields the line supplies, which are the only ones an update replaces.
+type importLine struct {
+	line   int
+	row    dto.ProductImportRowDTO
+	fields []string
+	err    error
+}
+
+// ImportProducts implements ProductService. Every row is validated like a create request;
+// valid rows are upserted by product_id in one bulk write while invalid rows are reported
+// as failed without affecting the others. Existing products only have the columns present
+// in the file replaced. A row that sets a stock-tracked product to an availability other than
+// the one its inventory gives fails. A dry run validates and reports without writing.
+func (s *ProductServiceV2) ImportProducts(ctx context.Context, format string, body io.Reader, dryRun bool) (*dto.ProductImportReport, error) {
+	var lines []importLine
+	var err error
+	switch format {
+	case dto.FormatCSV:
+		lines, err = parseCSVImport(body)
+	case dto.FormatNDJSON:
+		lines, err = parseNDJSONImport(body)
+	default:
+		return nil, ErrUnsupportedImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	report := &dto.ProductImportReport{
+		DryRun: dryRun,
+		Total:  len(lines),
+		Rows:   make([]dto.ProductImportRowResult, len(lines)),
+	}
+
+	sellerIDs, err := s.importedSellerIDs(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	var upserts []repository.ProductUpsert
+	var productRows []int
+	seenProductIDs := make(map[int]int)
+	for i, line := range lines {
+		result := &report.Rows[i]
+		result.Line = line.line
+		result.ProductID = line.row.ProductID
+
+		rowErr := line.err
+		if rowErr == nil {
+			rowErr = s.validateImportRow(ctx, line, seenProductIDs, sellerIDs)
+		}
+		if rowErr != nil {
+			if apperrors.Code(rowErr) == apperrors.CodeInternal {
+				return nil, rowErr
+			}
+			failImportRow(result, rowErr)
+			continue
+		}
+
+		product := dto.MapDTOToProduct(&line.row.ProductRequestDTO)
+		product.ProductID = line.row.ProductID
+		fields := line.fields
+		if product.BrandID > 0 && product.BrandName == "" {
+			brand, err := s.brandDAO.GetProductBrandByBrandID(ctx, product.BrandID)
+			if err == nil {
+				product.BrandName = brand.BrandName
+				fields = append(fields[:len(fields):len(fields)], "brand_name")
+			}
+		}
+
+		upserts = append(upserts, repository.ProductUpsert{Product: product, Fields: fields})
+		productRows = append(productRows, i)
+	}
+
+	upserts, productRows, err = s.checkStockAvailability(ctx, report, upserts, productRows)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		err = s.previewImport(ctx, report, upserts, productRows)
+	} else {
+		err = s.writeImport(ctx, report, upserts, productRows)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range report.Rows {
+		switch result.Status {
+		case dto.ImportStatusCreated:
+			report.Created++
+		case dto.ImportStatusUpdated:
+			report.Updated++
+		case dto.ImportStatusFailed:
+			report.Failed++
+		}
+	}
+
+	return report, nil
+}
+
+// importedSellerIDs returns the seller_id of each stored product the import lines update
+func (s *ProductServiceV2) importedSellerIDs(ctx context.Context, lines []importLine) (map[int]int, error) {
+	sellerIDs := make(map[int]int)
+
+	var productIDs []int
+	for _, line := range lines {
+		if line.err == nil && line.row.ProductID > 0 {
+			productIDs = append(productIDs, line.row.ProductID)
+		}
+	}
+	if len(productIDs) == 0 {
+		return sellerIDs, nil
+	}
+
+	products, err := s.productRepo.FindByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range products {
+		sellerIDs[product.ProductID] = product.SellerID
+	}
+	return sellerIDs, nil
+}
+
+// validateImportRow applies the product request rules to a row and rejects a product_id
+// that appears more than once in the same file. The seller of a product being updated is
+// only checked when the row changes it.
+func (s *ProductServiceV2) validateImportRow(ctx context.Context, line importLine, seenProductIDs map[int]int, sellerIDs map[int]int) error {
+	productID := line.row.ProductID
+	if productID < 0 {
+		return apperrors.Validation("product validation failed", map[string]string{
+			"product_id": "must not be negative",
+		})
+	}
+	if productID > 0 {
+		if firstLine, seen := seenProductIDs[productID]; seen {
+			return apperrors.Validation("product validation failed", map[string]string{
+				"product_id": "duplicates line " + strconv.Itoa(firstLine),
+			})
+		}
+		seenProductIDs[productID] = line.line
+	}
+
+	return s.validator.Validate(ctx, line.row.ProductRequestDTO, sellerIDs[productID])
+}
+
+// checkStockAvailability fails the rows that set a stock-tracked product to an availability
+// other than the one its inventory gives, and returns the remaining upserts and their rows
+func (s *ProductServiceV2) checkStockAvailability(ctx context.Context, report *dto.ProductImportReport, upserts []repository.ProductUpsert, productRows []int) ([]repository.ProductUpsert, []int, error) {
+	var productIDs []int
+	for _, upsert := range upserts {
+		if upsert.Product.ProductID > 0 {
+			productIDs = append(productIDs, upsert.Product.ProductID)
+		}
+	}
+
+	availability, err := stockAvailability(ctx, s.inventoryRepo, productIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var keptUpserts []repository.ProductUpsert
+	var keptRows []int
+	for i, upsert := range upserts {
+		available, tracked := availability[upsert.Product.ProductID]
+		if tracked && containsField(upsert.Fields, "availability") && upsert.Product.Availability != available {
+			failImportRow(&report.Rows[productRows[i]], ErrAvailabilityTracked)
+			continue
+		}
+		keptUpserts = append(keptUpserts, upsert)
+		keptRows = append(keptRows, productRows[i])
+	}
+	return keptUpserts, keptRows, nil
+}
+
+// containsField reports whether field is one of fields
+func containsField(fields []string, field string) bool {
+	for _, candidate := range fields {
+		if candidate == field {
+			return true
+		}
+	}
+	return false
+}
+
+// previewImport reports whether each valid row would create or update a product
+func (s *ProductServiceV2) previewImport(ctx context.Context, report *dto.ProductImportReport, upserts []repository.ProductUpsert, productRows []int) error {
+	var productIDs []int
+	for _, upsert := range upserts {
+		if upsert.Product.ProductID > 0 {
+			productIDs = append(productIDs, upsert.Product.ProductID)
+		}
+	}
+
+	existing, err := s.productRepo.FindExistingProductIDs(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	for i, upsert := range upserts {
+		result := &report.Rows[productRows[i]]
+		if existing[upsert.Product.ProductID] {
+			result.Status = dto.ImportStatusUpdated
+		} else {
+			result.Status = dto.ImportStatusCreated
+		}
+	}
+	return nil
+}
+
+// writeImport upserts the valid rows and records the outcome of each
+func (s *ProductServiceV2) writeImport(ctx context.Context, report *dto.ProductImportReport, upserts []repository.ProductUpsert, productRows []int) error {
+	outcomes, err := s.productRepo.BulkUpsertByProductID(ctx, upserts)
+	if err != nil {
+		return err
+	}
+
+	for i, outcome := range outcomes {
+		result := &report.Rows[productRows[i]]
+		result.ProductID = upserts[i].Product.ProductID
+		switch {
+		case outcome.Err != nil:
+			failImportRow(result, outcome.Err)
+		case outcome.Created:
+			result.Status = dto.ImportStatusCreated
+		default:
+			result.Status = dto.ImportStatusUpdated
+		}
+	}
+	return nil
+}
+
+// failImportRow marks a row as failed, hiding infrastructure errors from the report
+func failImportRow(result *dto.ProductImportRowResult, err error) {
+	result.Status = dto.ImportStatusFailed
+	if apperrors.Code(err) == apperrors.CodeInternal {
+		log.Printf("Error importing product on line %d: %v", result.Line, err)
+		result.Error = "product could not be written"
+		return
+	}
+	result.Error = err.Error()
+	result.Details = apperrors.DetailsOf(err)
+}
+
+// parseCSVImport reads a CSV catalog whose header names the columns of ProductCSVColumns
+func parseCSVImport(body io.Reader) ([]importLine, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyImport
+	}
+	if err != nil {
+		return nil, apperrors.BadRequest("invalid CSV header")
+	}
+
+	knownColumns := make(map[string]bool, len(dto.ProductCSVColumns))
+	for _, column := range dto.ProductCSVColumns {
+		knownColumns[column] = true
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !knownColumns[name] {
+			return nil, apperrors.BadRequest("unknown CSV column " + strconv.Quote(name))
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["product_name"]; !ok {
+		return nil, ErrInvalidImportHeader
+	}
+	if _, ok := columns["price"]; !ok {
+		return nil, ErrInvalidImportHeader
+	}
+
+	var fields []string
+	for name := range columns {
+		if name != "product_id" {
+			fields = append(fields, name)
+		}
+	}
+
+	var lines []importLine
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if len(lines) >= MaxImportRows {
+			return nil, ErrTooManyImportRows
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			lines = append(lines, importLine{
+				line: parseErr.StartLine,
+				err:  apperrors.BadRequest("invalid CSV: " + parseErr.Err.Error()),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, apperrors.Wrap(apperrors.ErrBadRequest, "could not read import body", err)
+		}
+
+		lineNumber, _ := reader.FieldPos(0)
+		row, err := parseCSVRow(columns, record)
+		lines = append(lines, importLine{line: lineNumber, row: row, fields: fields, err: err})
+	}
+
+	return lines, nil
+}
+
+// parseCSVRow converts a CSV record into an import row, collecting a message for every
+// column that cannot be parsed
+func parseCSVRow(columns map[string]int, record []string) (dto.ProductImportRowDTO, error) {
+	var row dto.ProductImportRowDTO
+	fieldErrors := make(map[string]string)
+
+	value := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for column, target := range map[string]*int{
+		"product_id": &row.ProductID,
+		"brand_id":   &row.BrandID,
+		"seller_id":  &row.SellerID,
+	} {
+		if valueStr := value(column); valueStr != "" {
+			parsed, err := strconv.Atoi(valueStr)
+			if err != nil {
+				fieldErrors[column] = "must be an integer"
+				continue
+			}
+			*target = parsed
+		}
+	}
+
+	for column, target := range map[string]*float64{
+		"price":    &row.Price,
+		"discount": &row.Discount,
+	} {
+		if valueStr := value(column); valueStr != "" {
+			parsed, err := strconv.ParseFloat(valueStr, 64)
+			if err != nil {
+				fieldErrors[column] = "must be a number"
+				continue
+			}
+			*target = parsed
+		}
+	}
+
+	if valueStr := value("availability"); valueStr != "" {
+		availability, err := strconv.ParseBool(valueStr)
+		if err != nil {
+			fieldErrors["availability"] = "must be true or false"
+		}
+		row.Availability = availability
+	}
+
+	if valueStr := value("item_dimensions"); valueStr != "" {
+		if err := json.Unmarshal([]byte(valueStr), &row.ItemDimensions); err != nil {
+			fieldErrors["item_dimensions"] = "must be a JSON object"
+		}
+	}
+
+	row.ProductName = value("product_name")
+	row.Description = value("description")
+	row.BrandName = value("brand_name")
+	row.Category = value("category")
+
+	if len(fieldErrors) > 0 {
+		return row, apperrors.Validation("row could not be parsed", fieldErrors)
+	}
+	return row, nil
+}
+
+// parseNDJSONImport reads a catalog with one JSON product object per line, skipping blank lines
+func parseNDJSONImport(body io.Reader) ([]importLine, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), MaxImportLineBytes)
+
+	var lines []importLine
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(lines) >= MaxImportRows {
+			return nil, ErrTooManyImportRows
+		}
+
+		line := importLine{line: lineNumber}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&line.row); err != nil {
+			line.err = apperrors.BadRequest("invalid JSON: " + err.Error())
+		} else {
+			line.fields = ndjsonFields(text)
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, apperrors.BadRequest("import line " + strconv.Itoa(lineNumber+1) + " is too long")
+		}
+		return nil, apperrors.Wrap(apperrors.ErrBadRequest, "could not read import body", err)
+	}
+
+	return lines, nil
+}
+
+// ndjsonFields returns the product fields named by the keys of a decoded NDJSON object.
+// Keys match fields case-insensitively, as they do when decoding.
+func ndjsonFields(text []byte) []string {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(text, &object); err != nil {
+		return nil
+	}
+
+	var fields []string
+	for key := range object {
+		if field := strings.ToLower(key); field != "product_id" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// importLineSummary is the part of a parsed import line the parser tests compare
+type importLineSummary struct {
+	line        int
+	productID   int
+	productName string
+	errCode     string
+}
+
+func summarizeImportLines(lines []importLine) []importLineSummary {
+	summaries := make([]importLineSummary, len(lines))
+	for i, line := range lines {
+		summaries[i] = importLineSummary{line: line.line, productID: line.row.ProductID, productName: line.row.ProductName}
+		if line.err != nil {
+			summaries[i].errCode = apperrors.Code(line.err)
+		}
+	}
+	return summaries
+}
+
+func TestParseCSVImport(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		want       []importLineSummary
+		wantFields []string
+		wantErr    error
+		wantCode   string
+	}{
+		{
+			name: "creates and updates",
+			body: "product_id,product_name,price\n7,Kibble,12.5\n,Treats,3\n",
+			want: []importLineSummary{
+				{line: 2, productID: 7, productName: "Kibble"},
+				{line: 3, productName: "Treats"},
+			},
+			wantFields: []string{"price", "product_name"},
+		},
+		{
+			name:       "byte order mark and header case",
+			body:       "\ufeffProduct_Name, PRICE\nKibble,12.5\n",
+			want:       []importLineSummary{{line: 2, productName: "Kibble"}},
+			wantFields: []string{"price", "product_name"},
+		},
+		{
+			name: "unparseable row is reported",
+			body: "product_name,price\nKibble,abc\nTreats,3\n",
+			want: []importLineSummary{
+				{line: 2, productName: "Kibble", errCode: apperrors.CodeValidation},
+				{line: 3, productName: "Treats"},
+			},
+			wantFields: []string{"price", "product_name"},
+		},
+		{
+			name: "malformed CSV is reported",
+			body: "product_name,price\n\"Kibble,12.5\n",
+			want: []importLineSummary{{line: 2, errCode: apperrors.CodeBadRequest}},
+		},
+		{name: "empty body", body: "", wantErr: ErrEmptyImport},
+		{name: "missing price column", body: "product_name\nKibble\n", wantErr: ErrInvalidImportHeader},
+		{name: "missing name column", body: "price\n12.5\n", wantErr: ErrInvalidImportHeader},
+		{name: "unknown column", body: "product_name,price,colour\nKibble,12.5,red\n", wantCode: apperrors.CodeBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := parseCSVImport(strings.NewReader(tt.body))
+			if tt.wantErr != nil || tt.wantCode != "" {
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("parseCSVImport() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantCode != "" && apperrors.Code(err) != tt.wantCode {
+					t.Fatalf("parseCSVImport() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCSVImport() error = %v", err)
+			}
+
+			if got := summarizeImportLines(lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCSVImport() lines = %+v, want %+v", got, tt.want)
+			}
+			for _, line := range lines {
+				if line.err != nil {
+					continue
+				}
+				fields := append([]string(nil), line.fields...)
+				sort.Strings(fields)
+				if !reflect.DeepEqual(fields, tt.wantFields) {
+					t.Errorf("line %d fields = %v, want %v", line.line, fields, tt.wantFields)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCSVImportRowLimit(t *testing.T) {
+	body := "product_name,price\n" + strings.Repeat("Kibble,12.5\n", MaxImportRows+1)
+	if _, err := parseCSVImport(strings.NewReader(body)); !errors.Is(err, ErrTooManyImportRows) {
+		t.Errorf("parseCSVImport() error = %v, want %v", err, ErrTooManyImportRows)
+	}
+}
+
+func TestParseCSVRow(t *testing.T) {
+	columns := make(map[string]int, len(dto.ProductCSVColumns))
+	for i, column := range dto.ProductCSVColumns {
+		columns[column] = i
+	}
+	record := func(values map[string]string) []string {
+		fields := make([]string, len(dto.ProductCSVColumns))
+		for column, value := range values {
+			fields[columns[column]] = value
+		}
+		return fields
+	}
+
+	tests := []struct {
+		name           string
+		record         []string
+		want           dto.ProductImportRowDTO
+		wantFieldError []string
+	}{
+		{
+			name: "every column",
+			record: record(map[string]string{
+				"product_id":      "7",
+				"product_name":    " Kibble ",
+				"description":     "Dry food",
+				"brand_id":        "3",
+				"brand_name":      "Acme",
+				"seller_id":       "9",
+				"category":        "dog-food",
+				"item_dimensions": `{"weight":"2kg"}`,
+				"price":           "12.5",
+				"discount":        "10",
+				"availability":    "true",
+			}),
+			want: dto.ProductImportRowDTO{
+				ProductID: 7,
+				ProductRequestDTO: dto.ProductRequestDTO{
+					ProductName:    "Kibble",
+					Description:    "Dry food",
+					BrandID:        3,
+					BrandName:      "Acme",
+					SellerID:       9,
+					Category:       "dog-food",
+					ItemDimensions: map[string]interface{}{"weight": "2kg"},
+					Price:          12.5,
+					Discount:       10,
+					Availability:   true,
+				},
+			},
+		},
+		{
+			name:   "short record leaves missing columns empty",
+			record: []string{"", "Kibble"},
+			want:   dto.ProductImportRowDTO{ProductRequestDTO: dto.ProductRequestDTO{ProductName: "Kibble"}},
+		},
+		{
+			name: "unparseable columns",
+			record: record(map[string]string{
+				"product_id":      "seven",
+				"brand_id":        "1.5",
+				"seller_id":       "x",
+				"price":           "cheap",
+				"discount":        "%",
+				"availability":    "maybe",
+				"item_dimensions": "2kg",
+			}),
+			wantFieldError: []string{"availability", "brand_id", "discount", "item_dimensions", "price", "product_id", "seller_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, err := parseCSVRow(columns, tt.record)
+			if len(tt.wantFieldError) > 0 {
+				details, _ := apperrors.DetailsOf(err).(map[string]string)
+				var fields []string
+				for field := range details {
+					fields = append(fields, field)
+				}
+				sort.Strings(fields)
+				if !reflect.DeepEqual(fields, tt.wantFieldError) {
+					t.Errorf("parseCSVRow() field errors = %v, want %v", details, tt.wantFieldError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCSVRow() error = %v", err)
+			}
+			if !reflect.DeepEqual(row, tt.want) {
+				t.Errorf("parseCSVRow() = %+v, want %+v", row, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNDJSONImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []importLineSummary
+		wantErr error
+	}{
+		{
+			name: "blank lines keep line numbers",
+			body: "{\"product_id\":7,\"product_name\":\"Kibble\",\"price\":12.5}\n\n  \n{\"product_name\":\"Treats\",\"price\":3}\n",
+			want: []importLineSummary{
+				{line: 1, productID: 7, productName: "Kibble"},
+				{line: 4, productName: "Treats"},
+			},
+		},
+		{
+			name: "invalid lines are reported",
+			body: "{\"product_name\":\"Kibble\",\"colour\":\"red\"}\nnot json\n{\"product_name\":\"Treats\"}\n",
+			want: []importLineSummary{
+				{line: 1, productName: "Kibble", errCode: apperrors.CodeBadRequest},
+				{line: 2, errCode: apperrors.CodeBadRequest},
+				{line: 3, productName: "Treats"},
+			},
+		},
+		{name: "only blank lines", body: "\n\n", want: []importLineSummary{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := parseNDJSONImport(strings.NewReader(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseNDJSONImport() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := summarizeImportLines(lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNDJSONImport() lines = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNDJSONFields(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"product_id is not a field", `{"product_id":7,"price":12.5}`, []string{"price"}},
+		{"keys are lowercased", `{"Product_Name":"Kibble","AVAILABILITY":true}`, []string{"availability", "product_name"}},
+		{"null values are still supplied", `{"description":null}`, []string{"description"}},
+		{"empty object", `{}`, nil},
+		{"not an object", `[1,2]`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ndjsonFields([]byte(tt.text))
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ndjsonFields(%s) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateImportRow(t *testing.T) {
+	s := &ProductServiceV2{validator: NewProductValidator(nil, nil)}
+	valid := dto.ProductRequestDTO{ProductName: "Kibble", Price: 12.5}
+	withSeller := valid
+	withSeller.SellerID = 9
+	withoutPrice := valid
+	withoutPrice.Price = 0
+
+	tests := []struct {
+		name           string
+		line           importLine
+		seenProductIDs map[int]int
+		sellerIDs      map[int]int
+		wantFieldError map[string]string
+	}{
+		{
+			name: "new product",
+			line: importLine{line: 2, row: dto.ProductImportRowDTO{ProductRequestDTO: valid}},
+		},
+		{
+			name:      "update keeps its seller",
+			line:      importLine{line: 2, row: dto.ProductImportRowDTO{ProductID: 7, ProductRequestDTO: withSeller}},
+			sellerIDs: map[int]int{7: 9},
+		},
+		{
+			name:           "negative product_id",
+			line:           importLine{line: 2, row: dto.ProductImportRowDTO{ProductID: -1, ProductRequestDTO: valid}},
+			wantFieldError: map[string]string{"product_id": "must not be negative"},
+		},
+		{
+			name:           "product_id repeated in the file",
+			line:           importLine{line: 5, row: dto.ProductImportRowDTO{ProductID: 7, ProductRequestDTO: valid}},
+			seenProductIDs: map[int]int{7: 2},
+			wantFieldError: map[string]string{"product_id": "duplicates line 2"},
+		},
+		{
+			name:           "product rules apply",
+			line:           importLine{line: 2, row: dto.ProductImportRowDTO{ProductRequestDTO: withoutPrice}},
+			wantFieldError: map[string]string{"price": "is required and must be greater than 0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seenProductIDs := tt.seenProductIDs
+			if seenProductIDs == nil {
+				seenProductIDs = make(map[int]int)
+			}
+
+			err := s.validateImportRow(context.Background(), tt.line, seenProductIDs, tt.sellerIDs)
+			if tt.wantFieldError == nil {
+				if err != nil {
+					t.Fatalf("validateImportRow() error = %v", err)
+				}
+				if productID := tt.line.row.ProductID; productID > 0 && seenProductIDs[productID] != tt.line.line {
+					t.Errorf("product_id %d not recorded as seen on line %d", productID, tt.line.line)
+				}
+				return
+			}
+			if !errors.Is(err, apperrors.ErrValidation) {
+				t.Fatalf("validateImportRow() error = %v, want a validation error", err)
+			}
+			if details := apperrors.DetailsOf(err); !reflect.DeepEqual(details, tt.wantFieldError) {
+				t.Errorf("validateImportRow() details = %v, want %v", details, tt.wantFieldError)
+			}
+		})
+	}
+}
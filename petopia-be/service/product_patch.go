@@ -0,0 +1,135 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"time"
+)
+
+// patchableProductFields lists the product fields a merge patch may change
+var patchableProductFields = map[string]bool{
+	"product_name":    true,
+	"description":     true,
+	"brand_id":        true,
+	"brand_name":      true,
+	"seller_id":       true,
+	"category":        true,
+	"item_dimensions": true,
+	"price":           true,
+	"discount":        true,
+	"availability":    true,
+}
+
+// PatchProduct implements ProductService by applying a JSON Merge Patch (RFC 7386)
+// to the stored product, so omitted fields and created_at are left untouched. Changing
+// the availability of a stock-tracked product is rejected, as it follows the inventory.
+func (s *ProductServiceV2) PatchProduct(ctx context.Context, id string, patch []byte, expectedUpdatedAt *time.Time) (*dto.ProductResponseDTO, error) {
+	product, err := s.productRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if expectedUpdatedAt != nil && !sameVersion(product.UpdatedAt, *expectedUpdatedAt) {
+		return nil, repository.ErrProductModified
+	}
+
+	var patchDoc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(patch))
+	decoder.UseNumber()
+	if err := decoder.Decode(&patchDoc); err != nil || patchDoc == nil {
+		return nil, apperrors.BadRequest("patch must be a JSON object")
+	}
+
+	fieldErrors := make(map[string]string)
+	for field := range patchDoc {
+		if !patchableProductFields[field] {
+			fieldErrors[field] = "cannot be patched"
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return nil, apperrors.Validation("product patch rejected", fieldErrors)
+	}
+
+	current, err := json.Marshal(dto.MapProductToRequestDTO(product))
+	if err != nil {
+		return nil, err
+	}
+	var currentDoc map[string]interface{}
+	if err := json.Unmarshal(current, &currentDoc); err != nil {
+		return nil, err
+	}
+
+	merged, err := json.Marshal(mergePatch(currentDoc, patchDoc))
+	if err != nil {
+		return nil, err
+	}
+	var requestDTO dto.ProductRequestDTO
+	if err := json.Unmarshal(merged, &requestDTO); err != nil {
+		return nil, apperrors.BadRequest("patch contains a value of the wrong type")
+	}
+
+	// A new brand_id without a brand_name picks up the name of the new brand
+	if _, brandChanged := patchDoc["brand_id"]; brandChanged && patchDoc["brand_name"] == nil && requestDTO.BrandID > 0 {
+		if brand, err := s.brandDAO.GetProductBrandByBrandID(ctx, requestDTO.BrandID); err == nil {
+			requestDTO.BrandName = brand.BrandName
+		}
+	}
+
+	if err := s.validator.Validate(ctx, requestDTO, product.SellerID); err != nil {
+		return nil, err
+	}
+
+	availability, err := stockAvailability(ctx, s.inventoryRepo, []int{product.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	if available, tracked := availability[product.ProductID]; tracked {
+		if _, patched := patchDoc["availability"]; patched && requestDTO.Availability != available {
+			return nil, ErrAvailabilityTracked
+		}
+		requestDTO.Availability = available
+	}
+
+	// Always guard the write with the version that was read, so a concurrent
+	// change between the read above and this update is not overwritten
+	updated := dto.MapDTOToProduct(&requestDTO, id)
+	if err := s.productRepo.Update(ctx, id, updated, &product.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return s.GetProductByID(ctx, id)
+}
+
+// mergePatch applies an RFC 7386 merge patch: null removes a member, objects merge recursively
+// and any other value replaces the target member
+func mergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	if target == nil {
+		target = make(map[string]interface{})
+	}
+
+	for key, patchValue := range patch {
+		if patchValue == nil {
+			delete(target, key)
+			continue
+		}
+
+		patchObject, isObject := patchValue.(map[string]interface{})
+		if !isObject {
+			target[key] = patchValue
+			continue
+		}
+
+		targetObject, _ := target[key].(map[string]interface{})
+		target[key] = mergePatch(targetObject, patchObject)
+	}
+
+	return target
+}
+
+// sameVersion compares two updated_at values at the millisecond precision MongoDB stores
+func sameVersion(a, b time.Time) bool {
+	return a.UnixMilli() == b.UnixMilli()
+}
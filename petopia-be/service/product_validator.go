@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	"petopia-be/dao"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"strings"
+)
+
+// Limits enforced on product requests
+const (
+	MaxProductNameLength = 255
+	MaxDiscountPercent   = 100
+)
+
+// KnownProductCategories lists the categories a product can be filed under
+var KnownProductCategories = map[string]bool{
+	"dog-food":            true,
+	"cat-food":            true,
+	"raw-food":            true,
+	"freeze-dried":        true,
+	"sustainable-food":    true,
+	"fish-food":           true,
+	"bird-food":           true,
+	"supplements":         true,
+	"natural-treats":      true,
+	"medication":          true,
+	"dog-toys":            true,
+	"cat-toys":            true,
+	"interactive-toys":    true,
+	"eco-toys":            true,
+	"bird-toys":           true,
+	"grooming":            true,
+	"aquarium-supplies":   true,
+	"water-treatment":     true,
+	"small-animal-care":   true,
+	"beds":                true,
+	"blankets":            true,
+	"comfort-accessories": true,
+	"smart-feeders":       true,
+	"gps-trackers":        true,
+	"health-monitors":     true,
+	"health-monitoring":   true,
+	"biodegradable-waste": true,
+}
+
+// ProductValidator checks product requests before they are stored
+type ProductValidator struct {
+	brandDAO   *dao.ProductBrandDAO
+	sellerRepo repository.SellerRepository
+}
+
+// NewProductValidator creates a new ProductValidator
+func NewProductValidator(brandDAO *dao.ProductBrandDAO, sellerRepo repository.SellerRepository) *ProductValidator {
+	return &ProductValidator{
+		brandDAO:   brandDAO,
+		sellerRepo: sellerRepo,
+	}
+}
+
+// Validate checks required fields, numeric ranges, the category and the referenced brand and seller.
+// The seller is only looked up when it differs from currentSellerID, the seller of the stored
+// product or 0 for a new one, so products keep a seller that predates the seller records.
+// It returns a Validation error whose details map each invalid field to a message.
+func (v *ProductValidator) Validate(ctx context.Context, requestDTO dto.ProductRequestDTO, currentSellerID int) error {
+	fieldErrors := make(map[string]string)
+
+	name := strings.TrimSpace(requestDTO.ProductName)
+	if name == "" {
+		fieldErrors["product_name"] = "is required"
+	} else if len(name) > MaxProductNameLength {
+		fieldErrors["product_name"] = "must be at most 255 characters"
+	}
+
+	if requestDTO.Price <= 0 {
+		fieldErrors["price"] = "is required and must be greater than 0"
+	}
+
+	if requestDTO.Discount < 0 || requestDTO.Discount > MaxDiscountPercent {
+		fieldErrors["discount"] = "must be between 0 and 100"
+	}
+
+	if requestDTO.SellerID < 0 {
+		fieldErrors["seller_id"] = "must not be negative"
+	} else if requestDTO.SellerID > 0 && requestDTO.SellerID != currentSellerID {
+		_, err := v.sellerRepo.FindByID(ctx, requestDTO.SellerID)
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			fieldErrors["seller_id"] = "does not reference an existing seller"
+		case err != nil:
+			return err
+		}
+	}
+
+	if requestDTO.Category != "" && !KnownProductCategories[requestDTO.Category] {
+		fieldErrors["category"] = "is not a known category"
+	}
+
+	if requestDTO.BrandID < 0 {
+		fieldErrors["brand_id"] = "must not be negative"
+	} else if requestDTO.BrandID > 0 {
+		brand, err := v.brandDAO.GetProductBrandByBrandID(ctx, requestDTO.BrandID)
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			fieldErrors["brand_id"] = "does not reference an existing brand"
+		case err != nil:
+			return err
+		case !brand.IsActive:
+			fieldErrors["brand_id"] = "references an inactive brand"
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return apperrors.Validation("product validation failed", fieldErrors)
+	}
+	return nil
+}
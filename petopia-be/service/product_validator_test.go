@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	pgmodels "petopia-be/models/postgres"
+	"petopia-be/repository"
+	"reflect"
+	"testing"
+)
+
+// fakeSellerRepository serves FindByID from a fixed set of sellers and records the lookups
+type fakeSellerRepository struct {
+	repository.SellerRepository
+	sellers map[int]pgmodels.Seller
+	err     error
+	lookups []int
+}
+
+func (r *fakeSellerRepository) FindByID(ctx context.Context, id int) (*pgmodels.Seller, error) {
+	r.lookups = append(r.lookups, id)
+	if r.err != nil {
+		return nil, r.err
+	}
+	seller, ok := r.sellers[id]
+	if !ok {
+		return nil, apperrors.NotFound("seller not found")
+	}
+	return &seller, nil
+}
+
+func TestProductValidatorSeller(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+
+	tests := []struct {
+		name            string
+		sellerID        int
+		currentSellerID int
+		repoErr         error
+		wantLookups     []int
+		wantFieldError  map[string]string
+		wantErr         error
+	}{
+		{name: "no seller", sellerID: 0},
+		{name: "existing seller", sellerID: 9, wantLookups: []int{9}},
+		{name: "unchanged seller is not looked up", sellerID: 4, currentSellerID: 4},
+		{
+			name:           "unknown seller",
+			sellerID:       5,
+			wantLookups:    []int{5},
+			wantFieldError: map[string]string{"seller_id": "does not reference an existing seller"},
+		},
+		{
+			name:            "changed to an unknown seller",
+			sellerID:        5,
+			currentSellerID: 4,
+			wantLookups:     []int{5},
+			wantFieldError:  map[string]string{"seller_id": "does not reference an existing seller"},
+		},
+		{
+			name:           "negative seller",
+			sellerID:       -1,
+			wantFieldError: map[string]string{"seller_id": "must not be negative"},
+		},
+		{name: "lookup failure", sellerID: 9, repoErr: errDatabase, wantLookups: []int{9}, wantErr: errDatabase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sellerRepo := &fakeSellerRepository{
+				sellers: map[int]pgmodels.Seller{9: {ID: 9, SellerName: "Paws & Co"}},
+				err:     tt.repoErr,
+			}
+			validator := NewProductValidator(nil, sellerRepo)
+			requestDTO := dto.ProductRequestDTO{ProductName: "Kibble", Price: 12.5, SellerID: tt.sellerID}
+
+			err := validator.Validate(context.Background(), requestDTO, tt.currentSellerID)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantFieldError != nil:
+				if !errors.Is(err, apperrors.ErrValidation) {
+					t.Fatalf("Validate() error = %v, want a validation error", err)
+				}
+				if details := apperrors.DetailsOf(err); !reflect.DeepEqual(details, tt.wantFieldError) {
+					t.Errorf("Validate() details = %v, want %v", details, tt.wantFieldError)
+				}
+			case err != nil:
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(sellerRepo.lookups, tt.wantLookups) {
+				t.Errorf("seller lookups = %v, want %v", sellerRepo.lookups, tt.wantLookups)
+			}
+		})
+	}
+}
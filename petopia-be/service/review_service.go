@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"petopia-be/apperrors"
+	"petopia-be/dao"
+	"petopia-be/dto"
+	"petopia-be/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	mongomodels "petopia-be/models/mongo"
+)
+
+// Review errors
+var (
+	ErrReviewNotFound  = apperrors.NotFound("review not found")
+	ErrInvalidReviewID = apperrors.InvalidID("invalid review ID")
+	ErrInvalidRating   = apperrors.Validation("invalid review", map[string]string{
+		"rating": "must be between 1 and 5",
+	})
+)
+
+// Rating bounds for customer reviews
+const (
+	MinReviewRating = 1
+	MaxReviewRating = 5
+)
+
+// reviewSortFields maps the sort query values to review document sort keys
+var reviewSortFields = map[string]bson.D{
+	"recent":  {{Key: "created_at", Value: -1}},
+	"helpful": {{Key: "helpful_votes", Value: -1}, {Key: "created_at", Value: -1}},
+	"rating":  {{Key: "rating", Value: -1}, {Key: "created_at", Value: -1}},
+}
+
+// ReviewServiceImpl implements ReviewService using the customer review DAO
+type ReviewServiceImpl struct {
+	reviewDAO   *dao.CustomerReviewDAO
+	productRepo repository.ProductRepository
+	orderRepo   repository.OrderRepository
+}
+
+// NewReviewService creates a new ReviewService
+func NewReviewService(reviewDAO *dao.CustomerReviewDAO, productRepo repository.ProductRepository, orderRepo repository.OrderRepository) ReviewService {
+	return &ReviewServiceImpl{
+		reviewDAO:   reviewDAO,
+		productRepo: productRepo,
+		orderRepo:   orderRepo,
+	}
+}
+
+// GetProductReviews implements ReviewService
+func (s *ReviewServiceImpl) GetProductReviews(ctx context.Context, productID int, filter dto.ReviewFilter) (*dto.PaginatedResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+
+	query := bson.M{"product_id": productID}
+	if filter.MinRating != nil {
+		query["rating"] = bson.M{"$gte": *filter.MinRating}
+	}
+	if filter.VerifiedPurchase != nil {
+		query["verified_purchase"] = *filter.VerifiedPurchase
+	}
+	if filter.PetType != "" {
+		query["pet_info.pet_type"] = filter.PetType
+	}
+	if filter.PetBreed != "" {
+		query["pet_info.pet_breed"] = filter.PetBreed
+	}
+	if filter.PetSize != "" {
+		query["pet_info.pet_size"] = filter.PetSize
+	}
+
+	reviews, total, err := s.reviewDAO.GetReviewsByFilter(ctx, query, reviewSortFields[filter.SortBy], filter.Page, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.CreatePaginatedResponse(dto.MapReviewsToDTOs(reviews), total, filter.Page, filter.Limit), nil
+}
+
+// CreateReview implements ReviewService. verified_purchase is set when the customer has an
+// order containing the product.
+func (s *ReviewServiceImpl) CreateReview(ctx context.Context, productID int, requestDTO dto.ReviewRequestDTO) (*dto.ReviewResponseDTO, error) {
+	if err := validateRating(requestDTO.Rating); err != nil {
+		return nil, err
+	}
+
+	// Reviews reference products by product_id, so the product has to exist
+	if _, err := s.productRepo.FindByProductID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	review := dto.MapDTOToReview(&requestDTO, productID)
+
+	// A review is a verified purchase when its customer has ordered the product
+	if requestDTO.CustomerID > 0 {
+		verified, err := s.orderRepo.HasOrderedProduct(ctx, requestDTO.CustomerID, productID)
+		if err != nil {
+			return nil, err
+		}
+		review.VerifiedPurchase = verified
+	}
+
+	if err := s.reviewDAO.CreateCustomerReview(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return dto.MapReviewToDTO(review), nil
+}
+
+// UpdateReview implements ReviewService
+func (s *ReviewServiceImpl) UpdateReview(ctx context.Context, productID int, reviewID string, requestDTO dto.ReviewRequestDTO) (*dto.ReviewResponseDTO, error) {
+	if err := validateRating(requestDTO.Rating); err != nil {
+		return nil, err
+	}
+
+	review, err := s.findProductReview(ctx, productID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := bson.M{
+		"rating":   requestDTO.Rating,
+		"title":    requestDTO.Title,
+		"comment":  requestDTO.Comment,
+		"images":   requestDTO.Images,
+		"filters":  requestDTO.Filters,
+		"pet_info": requestDTO.PetInfo,
+	}
+	if err := s.reviewDAO.UpdateCustomerReview(ctx, review.ID, updates); err != nil {
+		return nil, err
+	}
+
+	updatedReview, err := s.reviewDAO.GetCustomerReviewByID(ctx, review.ID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapReviewToDTO(updatedReview), nil
+}
+
+// DeleteReview implements ReviewService
+func (s *ReviewServiceImpl) DeleteReview(ctx context.Context, productID int, reviewID string) error {
+	review, err := s.findProductReview(ctx, productID, reviewID)
+	if err != nil {
+		return err
+	}
+	return s.reviewDAO.DeleteCustomerReview(ctx, review.ID)
+}
+
+// MarkReviewHelpful implements ReviewService
+func (s *ReviewServiceImpl) MarkReviewHelpful(ctx context.Context, productID int, reviewID string) (*dto.ReviewResponseDTO, error) {
+	review, err := s.findProductReview(ctx, productID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewDAO.IncrementHelpfulVotes(ctx, review.ID); err != nil {
+		return nil, err
+	}
+
+	updatedReview, err := s.reviewDAO.GetCustomerReviewByID(ctx, review.ID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapReviewToDTO(updatedReview), nil
+}
+
+// findProductReview loads a review and makes sure it belongs to the given product
+func (s *ReviewServiceImpl) findProductReview(ctx context.Context, productID int, reviewID string) (*mongomodels.CustomerReview, error) {
+	objectID, err := primitive.ObjectIDFromHex(reviewID)
+	if err != nil {
+		return nil, ErrInvalidReviewID
+	}
+
+	review, err := s.reviewDAO.GetCustomerReviewByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.ProductID != productID {
+		return nil, ErrReviewNotFound
+	}
+
+	return review, nil
+}
+
+// validateRating checks that a rating is within the allowed star range
+func validateRating(rating float64) error {
+	if rating < MinReviewRating || rating > MaxReviewRating {
+		return ErrInvalidRating
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"strings"
+
+	pgmodels "petopia-be/models/postgres"
+)
+
+// MaxSellerNameLength matches the seller_info.seller_name column
+const MaxSellerNameLength = 255
+
+// Page sizes for seller listings
+const (
+	DefaultSellerPageSize = 10
+	MaxSellerPageSize     = 100
+)
+
+// ErrSellerHasProducts is returned when deleting a seller that still lists products
+var ErrSellerHasProducts = apperrors.Conflict("seller still has products; delete or reassign them first")
+
+// SellerServiceImpl implements SellerService
+type SellerServiceImpl struct {
+	sellerRepo     repository.SellerRepository
+	productRepo    repository.ProductRepository
+	productService ProductService
+}
+
+// NewSellerService creates a new SellerService
+func NewSellerService(
+	sellerRepo repository.SellerRepository,
+	productRepo repository.ProductRepository,
+	productService ProductService,
+) SellerService {
+	return &SellerServiceImpl{
+		sellerRepo:     sellerRepo,
+		productRepo:    productRepo,
+		productService: productService,
+	}
+}
+
+// CreateSeller implements SellerService
+func (s *SellerServiceImpl) CreateSeller(ctx context.Context, requestDTO dto.SellerRequestDTO, actorID int) (*dto.SellerResponseDTO, error) {
+	seller, err := validateSeller(requestDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	seller.CreatedBy = pgmodels.ActorRef(actorID)
+	if err := s.sellerRepo.Create(ctx, seller); err != nil {
+		return nil, err
+	}
+
+	return dto.MapSellerToDTO(seller), nil
+}
+
+// ListSellers implements SellerService
+func (s *SellerServiceImpl) ListSellers(ctx context.Context, page, limit int64) (*dto.PaginatedResponse, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultSellerPageSize
+	}
+	if limit > MaxSellerPageSize {
+		limit = MaxSellerPageSize
+	}
+
+	sellers, total, err := s.sellerRepo.FindAll(ctx, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.CreatePaginatedResponse(dto.MapSellersToDTOs(sellers), total, page, limit), nil
+}
+
+// GetSeller implements SellerService
+func (s *SellerServiceImpl) GetSeller(ctx context.Context, id int) (*dto.SellerResponseDTO, error) {
+	seller, err := s.sellerRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapSellerToDTO(seller), nil
+}
+
+// UpdateSeller implements SellerService
+func (s *SellerServiceImpl) UpdateSeller(ctx context.Context, id int, requestDTO dto.SellerRequestDTO, actorID int) (*dto.SellerResponseDTO, error) {
+	changes, err := validateSeller(requestDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	seller, err := s.sellerRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	seller.SellerName = changes.SellerName
+	seller.SellerInfo = changes.SellerInfo
+	seller.SellerAddress = changes.SellerAddress
+	if err := s.sellerRepo.Update(ctx, seller, actorID); err != nil {
+		return nil, err
+	}
+
+	return dto.MapSellerToDTO(seller), nil
+}
+
+// DeleteSeller implements SellerService
+func (s *SellerServiceImpl) DeleteSeller(ctx context.Context, id int, actorID int) error {
+	if _, err := s.sellerRepo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	_, total, err := s.productRepo.FindAll(ctx, dto.ProductFilter{SellerID: id, Page: 1, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return ErrSellerHasProducts
+	}
+
+	return s.sellerRepo.SoftDelete(ctx, id, actorID)
+}
+
+// ListSellerProducts implements SellerService
+func (s *SellerServiceImpl) ListSellerProducts(ctx context.Context, id int, filter dto.ProductFilter) (*dto.PaginatedResponse, error) {
+	if _, err := s.sellerRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	filter.SellerID = id
+	return s.productService.ListProducts(ctx, filter)
+}
+
+// validateSeller trims a seller request and checks its fields.
+// It returns a Validation error whose details map each invalid field to a message.
+func validateSeller(requestDTO dto.SellerRequestDTO) (*pgmodels.Seller, error) {
+	seller := &pgmodels.Seller{
+		SellerName:    strings.TrimSpace(requestDTO.SellerName),
+		SellerInfo:    strings.TrimSpace(requestDTO.SellerInfo),
+		SellerAddress: strings.TrimSpace(requestDTO.SellerAddress),
+	}
+
+	fieldErrors := make(map[string]string)
+	if seller.SellerName == "" {
+		fieldErrors["seller_name"] = "is required"
+	} else if len(seller.SellerName) > MaxSellerNameLength {
+		fieldErrors["seller_name"] = "must be at most 255 characters"
+	}
+	if seller.SellerAddress == "" {
+		fieldErrors["seller_address"] = "is required"
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, apperrors.Validation("seller validation failed", fieldErrors)
+	}
+	return seller, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateSeller(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        dto.SellerRequestDTO
+		wantName       string
+		wantInfo       string
+		wantAddress    string
+		wantFieldError map[string]string
+	}{
+		{
+			name:        "fields are trimmed",
+			request:     dto.SellerRequestDTO{SellerName: " Paws & Co ", SellerInfo: " Pet supplies ", SellerAddress: " 12 MG Road "},
+			wantName:    "Paws & Co",
+			wantInfo:    "Pet supplies",
+			wantAddress: "12 MG Road",
+		},
+		{
+			name:        "info is optional",
+			request:     dto.SellerRequestDTO{SellerName: "Paws & Co", SellerAddress: "12 MG Road"},
+			wantName:    "Paws & Co",
+			wantAddress: "12 MG Road",
+		},
+		{
+			name:        "longest name",
+			request:     dto.SellerRequestDTO{SellerName: strings.Repeat("a", MaxSellerNameLength), SellerAddress: "12 MG Road"},
+			wantName:    strings.Repeat("a", MaxSellerNameLength),
+			wantAddress: "12 MG Road",
+		},
+		{
+			name:           "name too long",
+			request:        dto.SellerRequestDTO{SellerName: strings.Repeat("a", MaxSellerNameLength+1), SellerAddress: "12 MG Road"},
+			wantFieldError: map[string]string{"seller_name": "must be at most 255 characters"},
+		},
+		{
+			name:           "blank name and address",
+			request:        dto.SellerRequestDTO{SellerName: "  ", SellerInfo: "Pet supplies"},
+			wantFieldError: map[string]string{"seller_name": "is required", "seller_address": "is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateSeller(tt.request)
+			if tt.wantFieldError != nil {
+				if !errors.Is(err, apperrors.ErrValidation) {
+					t.Fatalf("validateSeller() error = %v, want a validation error", err)
+				}
+				if details := apperrors.DetailsOf(err); !reflect.DeepEqual(details, tt.wantFieldError) {
+					t.Errorf("validateSeller() details = %v, want %v", details, tt.wantFieldError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateSeller() error = %v", err)
+			}
+			if got.SellerName != tt.wantName || got.SellerInfo != tt.wantInfo || got.SellerAddress != tt.wantAddress {
+				t.Errorf("validateSeller() = (%q, %q, %q), want (%q, %q, %q)",
+					got.SellerName, got.SellerInfo, got.SellerAddress, tt.wantName, tt.wantInfo, tt.wantAddress)
+			}
+		})
+	}
+}
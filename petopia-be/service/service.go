@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"io"
 	"petopia-be/dto"
+	"time"
 )
 
 // Common welcome message function
@@ -18,24 +20,238 @@ type ProductService interface {
 	// Get a product by its ID
 	GetProductByID(ctx context.Context, id string) (*dto.ProductResponseDTO, error)
 
+	// Get a product by its ID even if it has been soft deleted
+	GetProductByIDIncludingDeleted(ctx context.Context, id string) (*dto.ProductResponseDTO, error)
+
 	// Get a product by its product_id field
 	GetProductByProductID(ctx context.Context, productID int) (*dto.ProductResponseDTO, error)
 
-	// Get all products with pagination
-	GetAllProducts(ctx context.Context, page, limit int64) (*dto.PaginatedResponse, error)
+	// List products matching a filter with sorting and pagination
+	ListProducts(ctx context.Context, filter dto.ProductFilter) (*dto.PaginatedResponse, error)
+
+	// Get available products matching a filter with sorting and pagination
+	GetAvailableProducts(ctx context.Context, filter dto.ProductFilter) (*dto.PaginatedResponse, error)
+
+	// Update a product, optionally only if it is still at the expected updated_at version
+	UpdateProduct(ctx context.Context, id string, requestDTO dto.ProductRequestDTO, expectedUpdatedAt *time.Time) error
+
+	// Apply a JSON Merge Patch to a product, optionally only if it is still at the expected updated_at version
+	PatchProduct(ctx context.Context, id string, patch []byte, expectedUpdatedAt *time.Time) (*dto.ProductResponseDTO, error)
+
+	// Soft delete a product on behalf of a user
+	DeleteProduct(ctx context.Context, id string, deletedBy int) error
 
-	// Get products by category
-	GetProductsByCategory(ctx context.Context, category string) ([]dto.ProductResponseDTO, error)
+	// Restore a soft-deleted product
+	RestoreProduct(ctx context.Context, id string) (*dto.ProductResponseDTO, error)
 
-	// Get available products with pagination
-	GetAvailableProducts(ctx context.Context, page, limit int64) (*dto.PaginatedResponse, error)
+	// Permanently remove products soft deleted longer ago than the retention period, with their reviews
+	PurgeDeletedProducts(ctx context.Context, retention time.Duration) (int, error)
 
-	// Update a product
-	UpdateProduct(ctx context.Context, id string, requestDTO dto.ProductRequestDTO) error
+	// Apply a batch of price, discount, availability, category and delete operations on behalf of a user
+	BulkUpdateProducts(ctx context.Context, request dto.BulkProductRequestDTO, actorID int) (*dto.BulkProductResponseDTO, error)
 
-	// Delete a product
-	DeleteProduct(ctx context.Context, id string) error
+	// Import products from a CSV or NDJSON catalog, upserting by product_id, optionally as a dry run
+	ImportProducts(ctx context.Context, format string, body io.Reader, dryRun bool) (*dto.ProductImportReport, error)
+
+	// Open a streaming export of all products matching a filter as CSV, NDJSON or a Merchant XML feed
+	ExportProducts(ctx context.Context, filter dto.ProductFilter, format string) (*ProductExport, error)
 
 	// Search for products
 	SearchProducts(ctx context.Context, term string) ([]dto.ProductResponseDTO, error)
+
+	// Search for products through the text index, ranked by relevance with pagination
+	SearchProductsRanked(ctx context.Context, filter dto.ProductFilter) (*dto.PaginatedResponse, error)
+
+	// Search for products and return facet counts for categories, brands, availability and price buckets
+	SearchProductsFaceted(ctx context.Context, filter dto.ProductFilter, priceBoundaries []float64) (*dto.FacetedSearchResponse, error)
+}
+
+// BrandService defines the interface for brand business operations
+type BrandService interface {
+	// Create a new brand
+	CreateBrand(ctx context.Context, requestDTO dto.BrandRequestDTO) (*dto.BrandResponseDTO, error)
+
+	// Get a brand by its ID
+	GetBrandByID(ctx context.Context, id string) (*dto.BrandResponseDTO, error)
+
+	// Get a brand by its brand_id field
+	GetBrandByBrandID(ctx context.Context, brandID int) (*dto.BrandResponseDTO, error)
+
+	// Get all brands with pagination, optionally only active ones
+	GetAllBrands(ctx context.Context, page, limit int64, activeOnly bool) (*dto.PaginatedResponse, error)
+
+	// Get active brands selling in a category
+	GetBrandsByCategory(ctx context.Context, category string) ([]dto.BrandResponseDTO, error)
+
+	// Update a brand
+	UpdateBrand(ctx context.Context, id string, requestDTO dto.BrandRequestDTO) error
+
+	// Deactivate a brand
+	DeactivateBrand(ctx context.Context, id string) error
+
+	// Search for active brands
+	SearchBrands(ctx context.Context, term string) ([]dto.BrandResponseDTO, error)
+}
+
+// ReviewService defines the interface for customer review business operations
+type ReviewService interface {
+	// Get reviews for a product with filters and pagination
+	GetProductReviews(ctx context.Context, productID int, filter dto.ReviewFilter) (*dto.PaginatedResponse, error)
+
+	// Create a review for a product
+	CreateReview(ctx context.Context, productID int, requestDTO dto.ReviewRequestDTO) (*dto.ReviewResponseDTO, error)
+
+	// Update a review of a product
+	UpdateReview(ctx context.Context, productID int, reviewID string, requestDTO dto.ReviewRequestDTO) (*dto.ReviewResponseDTO, error)
+
+	// Delete a review of a product
+	DeleteReview(ctx context.Context, productID int, reviewID string) error
+
+	// Record a helpful vote for a review
+	MarkReviewHelpful(ctx context.Context, productID int, reviewID string) (*dto.ReviewResponseDTO, error)
+}
+
+// UserService defines the interface for user account and address business operations.
+// actorID is the user performing the change and is recorded in the audit columns.
+type UserService interface {
+	// Create a new user account
+	CreateUser(ctx context.Context, requestDTO dto.UserRequestDTO, actorID int) (*dto.UserResponseDTO, error)
+
+	// Get a user account with its addresses
+	GetUser(ctx context.Context, id int) (*dto.UserResponseDTO, error)
+
+	// Update a user account
+	UpdateUser(ctx context.Context, id int, requestDTO dto.UserRequestDTO, actorID int) (*dto.UserResponseDTO, error)
+
+	// Soft delete a user account and its addresses
+	DeleteUser(ctx context.Context, id int, actorID int) error
+
+	// List the addresses of a user
+	ListAddresses(ctx context.Context, userID int) ([]dto.AddressResponseDTO, error)
+
+	// Add an address to a user
+	AddAddress(ctx context.Context, userID int, requestDTO dto.AddressRequestDTO, actorID int) (*dto.AddressResponseDTO, error)
+
+	// Update an address of a user
+	UpdateAddress(ctx context.Context, userID, addressID int, requestDTO dto.AddressRequestDTO, actorID int) (*dto.AddressResponseDTO, error)
+
+	// Soft delete an address of a user
+	DeleteAddress(ctx context.Context, userID, addressID int, actorID int) error
+}
+
+// CartService defines the interface for shopping cart business operations.
+// Cart lines are addressed by product_id and priced from the product catalog.
+type CartService interface {
+	// Get a user's cart with line and cart totals
+	GetCart(ctx context.Context, userID int) (*dto.CartResponseDTO, error)
+
+	// Add an available product to a user's cart
+	AddItem(ctx context.Context, userID int, requestDTO dto.CartItemRequestDTO, actorID int) (*dto.CartResponseDTO, error)
+
+	// Change the quantity of a product in a user's cart
+	UpdateItemQuantity(ctx context.Context, userID, productID int, requestDTO dto.CartQuantityRequestDTO, actorID int) (*dto.CartResponseDTO, error)
+
+	// Remove a product from a user's cart
+	RemoveItem(ctx context.Context, userID, productID int, actorID int) error
+
+	// Remove every product from a user's cart
+	ClearCart(ctx context.Context, userID int, actorID int) error
+}
+
+// CheckoutService defines the interface for turning carts into orders
+type CheckoutService interface {
+	// Place an order for everything in a user's cart. With an idempotency key, a retry returns
+	// the order already placed with that key and created is false.
+	Checkout(ctx context.Context, requestDTO dto.CheckoutRequestDTO, idempotencyKey string, actorID int) (order *dto.OrderResponseDTO, created bool, err error)
+}
+
+// InventoryService defines the interface for stock management. Changing a product's stock
+// also derives its catalog availability from the summed available stock.
+type InventoryService interface {
+	// Get a product's stock per seller and location with totals
+	GetInventory(ctx context.Context, productID int) (*dto.InventoryResponseDTO, error)
+
+	// Add or remove stock a seller holds of a product at a location
+	AdjustStock(ctx context.Context, productID int, requestDTO dto.InventoryAdjustRequestDTO, actorID int) (*dto.InventoryResponseDTO, error)
+
+	// Hold available stock of a product for customers
+	ReserveStock(ctx context.Context, productID int, requestDTO dto.InventoryQuantityRequestDTO, actorID int) (*dto.InventoryResponseDTO, error)
+
+	// Return reserved stock of a product to available stock
+	ReleaseStock(ctx context.Context, productID int, requestDTO dto.InventoryQuantityRequestDTO, actorID int) (*dto.InventoryResponseDTO, error)
+
+	// List products whose available stock is at or below a threshold, or the configured one when nil
+	ListLowStock(ctx context.Context, threshold *int) (*dto.LowStockResponseDTO, error)
+
+	// Set the catalog availability of products from their stock; products without stock rows are left as they are
+	SyncAvailability(ctx context.Context, productIDs []int) error
+}
+
+// OrderService defines the interface for order history and the ordered product lifecycle
+type OrderService interface {
+	// Get a page of a user's orders, newest first
+	ListOrders(ctx context.Context, userID int, page, limit int64) (*dto.PaginatedResponse, error)
+
+	// Get an order of a user
+	GetOrder(ctx context.Context, userID, orderID int) (*dto.OrderResponseDTO, error)
+
+	// Move an ordered product to a new delivery or return status, rejecting moves the lifecycle does not allow
+	UpdateItemStatus(ctx context.Context, userID, orderID, itemID int, requestDTO dto.OrderItemStatusRequestDTO, actorID int) (*dto.OrderResponseDTO, error)
+}
+
+// ShippingService defines the interface for shipments and their tracking timelines
+type ShippingService interface {
+	// Ship products of a user's order, one shipment per ordered product
+	CreateShipments(ctx context.Context, userID, orderID int, requestDTO dto.ShipmentRequestDTO, actorID int) ([]dto.ShipmentResponseDTO, error)
+
+	// List the shipments of a user's order
+	ListShipments(ctx context.Context, userID, orderID int) ([]dto.ShipmentResponseDTO, error)
+
+	// Get a shipment by its ID
+	GetShipment(ctx context.Context, id int) (*dto.ShipmentResponseDTO, error)
+
+	// Assign the delivery partner of a shipment
+	AssignPartner(ctx context.Context, id int, requestDTO dto.DeliveryPartnerRequestDTO, actorID int) (*dto.ShipmentResponseDTO, error)
+
+	// Append a tracking event; a delivered event also marks the ordered product delivered
+	AddTrackingEvent(ctx context.Context, id int, requestDTO dto.TrackingEventRequestDTO, actorID int) (*dto.ShipmentTrackingResponseDTO, error)
+
+	// Get a shipment's tracking timeline
+	GetTracking(ctx context.Context, id int) (*dto.ShipmentTrackingResponseDTO, error)
+}
+
+// SellerService defines the interface for marketplace sellers and their catalog
+type SellerService interface {
+	// Create a new seller
+	CreateSeller(ctx context.Context, requestDTO dto.SellerRequestDTO, actorID int) (*dto.SellerResponseDTO, error)
+
+	// Get a page of sellers
+	ListSellers(ctx context.Context, page, limit int64) (*dto.PaginatedResponse, error)
+
+	// Get a seller by its ID
+	GetSeller(ctx context.Context, id int) (*dto.SellerResponseDTO, error)
+
+	// Update a seller
+	UpdateSeller(ctx context.Context, id int, requestDTO dto.SellerRequestDTO, actorID int) (*dto.SellerResponseDTO, error)
+
+	// Soft delete a seller that no longer has products
+	DeleteSeller(ctx context.Context, id int, actorID int) error
+
+	// List a seller's products with the standard product filters and pagination
+	ListSellerProducts(ctx context.Context, id int, filter dto.ProductFilter) (*dto.PaginatedResponse, error)
+}
+
+// CardService defines the interface for payment cards stored in the card vault
+type CardService interface {
+	// Validate, seal and save a payment card of a user
+	AddCard(ctx context.Context, userID int, requestDTO dto.CardRequestDTO, actorID int) (*dto.CardResponseDTO, error)
+
+	// List the saved cards of a user with masked numbers
+	ListCards(ctx context.Context, userID int) ([]dto.CardResponseDTO, error)
+
+	// Soft delete a saved card by its token
+	DeleteCard(ctx context.Context, userID int, token string, actorID int) error
+
+	// Seal every card still stored as a plain text number and return how many were sealed
+	SealLegacyCards(ctx context.Context) (int, error)
 }
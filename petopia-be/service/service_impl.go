@@ -2,21 +2,52 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"petopia-be/cardvault"
+	"petopia-be/config"
 	"petopia-be/dao"
 	"petopia-be/db"
 	"petopia-be/dto"
 	"petopia-be/repository"
+	"time"
+
+	mongomodels "petopia-be/models/mongo"
+
+	"gorm.io/gorm"
 )
 
+// DefaultPriceBuckets are the price facet boundaries used when the client does not configure any
+var DefaultPriceBuckets = []float64{0, 10, 25, 50, 100}
+
 // ServiceContainer holds all services
 type ServiceContainer struct {
-	ProductService ProductService
+	ProductService   ProductService
+	BrandService     BrandService
+	ReviewService    ReviewService
+	UserService      UserService
+	CartService      CartService
+	CheckoutService  CheckoutService
+	InventoryService InventoryService
+	OrderService     OrderService
+	ShippingService  ShippingService
+	SellerService    SellerService
+	CardService      CardService
 }
 
-// NewServiceContainer creates a new service container with all services
-func NewServiceContainer() *ServiceContainer {
+// NewServiceContainer creates a new service container with all services. It fails when a
+// configured card vault key is invalid.
+func NewServiceContainer(cfg *config.Config, gormDB *gorm.DB) (*ServiceContainer, error) {
 	// Initialize repositories
 	productRepo := repository.NewMongoProductRepository()
+	brandRepo := repository.NewMongoBrandRepository()
+	userRepo := repository.NewPostgresUserRepository(gormDB)
+	cartRepo := repository.NewPostgresCartRepository(gormDB)
+	orderRepo := repository.NewPostgresOrderRepository(gormDB)
+	inventoryRepo := repository.NewPostgresInventoryRepository(gormDB)
+	shippingRepo := repository.NewPostgresShippingRepository(gormDB)
+	sellerRepo := repository.NewPostgresSellerRepository(gormDB)
+	cardRepo := repository.NewPostgresCardRepository(gormDB)
+	catalogRepo := repository.NewPostgresProductCatalogRepository(gormDB)
 
 	// Initialize DAOs
 	brandCollection := db.GetMongoCollection("brands")
@@ -25,37 +56,85 @@ func NewServiceContainer() *ServiceContainer {
 	brandDAO := dao.NewProductBrandDAO(brandCollection)
 	reviewDAO := dao.NewCustomerReviewDAO(reviewCollection)
 
+	// Storefront settings used in product feeds
+	feedConfig := ProductFeedConfig{
+		StorefrontURL: cfg.StorefrontURL,
+		Currency:      cfg.CatalogCurrency,
+	}
+
+	// Card operations are disabled until a vault key is configured
+	var vault *cardvault.Vault
+	if cfg.CardVaultKey != "" {
+		var err error
+		if vault, err = cardvault.New(cfg.CardVaultKey); err != nil {
+			return nil, fmt.Errorf("invalid CARD_VAULT_KEY: %w", err)
+		}
+	}
+
 	// Create services
-	productService := NewProductServiceV2(productRepo, brandDAO, reviewDAO)
+	productService := NewProductServiceV2(productRepo, inventoryRepo, brandDAO, reviewDAO, sellerRepo, feedConfig)
+	brandService := NewBrandService(brandRepo, brandDAO)
+	reviewService := NewReviewService(reviewDAO, productRepo, orderRepo)
+	userService := NewUserService(userRepo)
+	cartService := NewCartService(cartRepo, userRepo, productRepo, catalogRepo)
+	inventoryService := NewInventoryService(inventoryRepo, productRepo, catalogRepo, cfg.LowStockThreshold)
+	checkoutService := NewCheckoutService(orderRepo, cartRepo, userRepo, productRepo, inventoryService)
+	orderService := NewOrderService(orderRepo, userRepo)
+	shippingService := NewShippingService(shippingRepo, orderRepo)
+	sellerService := NewSellerService(sellerRepo, productRepo, productService)
+	cardService := NewCardService(cardRepo, userRepo, vault)
 
 	return &ServiceContainer{
-		ProductService: productService,
-	}
+		ProductService:   productService,
+		BrandService:     brandService,
+		ReviewService:    reviewService,
+		UserService:      userService,
+		CartService:      cartService,
+		CheckoutService:  checkoutService,
+		InventoryService: inventoryService,
+		OrderService:     orderService,
+		ShippingService:  shippingService,
+		SellerService:    sellerService,
+		CardService:      cardService,
+	}, nil
 }
 
 // ProductServiceV2 is the new implementation of ProductService using the repository pattern
 type ProductServiceV2 struct {
-	productRepo repository.ProductRepository
-	brandDAO    *dao.ProductBrandDAO
-	reviewDAO   *dao.CustomerReviewDAO
+	productRepo   repository.ProductRepository
+	inventoryRepo repository.InventoryRepository
+	brandDAO      *dao.ProductBrandDAO
+	reviewDAO     *dao.CustomerReviewDAO
+	validator     *ProductValidator
+	feedConfig    ProductFeedConfig
 }
 
 // NewProductServiceV2 creates a new ProductServiceV2
 func NewProductServiceV2(
 	productRepo repository.ProductRepository,
+	inventoryRepo repository.InventoryRepository,
 	brandDAO *dao.ProductBrandDAO,
 	reviewDAO *dao.CustomerReviewDAO,
+	sellerRepo repository.SellerRepository,
+	feedConfig ProductFeedConfig,
 ) ProductService {
 	return &ProductServiceV2{
-		productRepo: productRepo,
-		brandDAO:    brandDAO,
-		reviewDAO:   reviewDAO,
+		productRepo:   productRepo,
+		inventoryRepo: inventoryRepo,
+		brandDAO:      brandDAO,
+		reviewDAO:     reviewDAO,
+		validator:     NewProductValidator(brandDAO, sellerRepo),
+		feedConfig:    feedConfig,
 	}
 }
 
 // Implementation of ProductService interface using the repository pattern
 // CreateProduct implements ProductService
 func (s *ProductServiceV2) CreateProduct(ctx context.Context, requestDTO dto.ProductRequestDTO) (*dto.ProductResponseDTO, error) {
+	if err := s.validator.Validate(ctx, requestDTO, 0); err != nil {
+		return nil, err
+	}
+
 	// Convert DTO to model
 	product := dto.MapDTOToProduct(&requestDTO)
 
@@ -83,7 +162,16 @@ func (s *ProductServiceV2) GetProductByID(ctx context.Context, id string) (*dto.
 	if err != nil {
 		return nil, err
 	}
-	return dto.MapProductToDTO(product), nil
+	return s.mapProductWithRating(ctx, product)
+}
+
+// GetProductByIDIncludingDeleted implements ProductService
+func (s *ProductServiceV2) GetProductByIDIncludingDeleted(ctx context.Context, id string) (*dto.ProductResponseDTO, error) {
+	product, err := s.productRepo.FindByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapProductWithRating(ctx, product)
 }
 
 // GetProductByProductID implements ProductService
@@ -92,66 +180,65 @@ func (s *ProductServiceV2) GetProductByProductID(ctx context.Context, productID
 	if err != nil {
 		return nil, err
 	}
-	return dto.MapProductToDTO(product), nil
+	return s.mapProductWithRating(ctx, product)
 }
 
-// GetAllProducts implements ProductService
-func (s *ProductServiceV2) GetAllProducts(ctx context.Context, page, limit int64) (*dto.PaginatedResponse, error) {
-	if page <= 0 {
-		page = 1
+// ListProducts implements ProductService
+func (s *ProductServiceV2) ListProducts(ctx context.Context, filter dto.ProductFilter) (*dto.PaginatedResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
 	}
-	if limit <= 0 {
-		limit = 10
+	if filter.Limit <= 0 {
+		filter.Limit = 10
 	}
 
-	products, err := s.productRepo.FindAll(ctx, nil, page, limit)
-	if err != nil {
-		return nil, err
+	if filter.UseCursor || filter.Cursor != "" {
+		products, cursorPage, err := s.productRepo.FindAllByCursor(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		productDTOs, err := s.mapProductsWithRatings(ctx, products)
+		if err != nil {
+			return nil, err
+		}
+		return dto.CreateCursorPaginatedResponse(productDTOs, cursorPage.Total, filter.Limit, cursorPage.NextCursor, cursorPage.PrevCursor), nil
 	}
 
-	total, err := s.productRepo.Count(ctx, nil)
+	products, total, err := s.productRepo.FindAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	productDTOs := dto.MapProductsToDTOs(products)
-	return dto.CreatePaginatedResponse(productDTOs, total, page, limit), nil
-}
-
-// GetProductsByCategory implements ProductService
-func (s *ProductServiceV2) GetProductsByCategory(ctx context.Context, category string) ([]dto.ProductResponseDTO, error) {
-	products, err := s.productRepo.FindByCategory(ctx, category)
+	productDTOs, err := s.mapProductsWithRatings(ctx, products)
 	if err != nil {
 		return nil, err
 	}
-	return dto.MapProductsToDTOs(products), nil
+	return dto.CreatePaginatedResponse(productDTOs, total, filter.Page, filter.Limit), nil
 }
 
 // GetAvailableProducts implements ProductService
-func (s *ProductServiceV2) GetAvailableProducts(ctx context.Context, page, limit int64) (*dto.PaginatedResponse, error) {
-	if page <= 0 {
-		page = 1
-	}
-	if limit <= 0 {
-		limit = 10
-	}
-
-	products, total, err := s.productRepo.FindAvailable(ctx, page, limit)
-	if err != nil {
-		return nil, err
-	}
-
-	productDTOs := dto.MapProductsToDTOs(products)
-	return dto.CreatePaginatedResponse(productDTOs, total, page, limit), nil
+func (s *ProductServiceV2) GetAvailableProducts(ctx context.Context, filter dto.ProductFilter) (*dto.PaginatedResponse, error) {
+	available := true
+	filter.Availability = &available
+	return s.ListProducts(ctx, filter)
 }
 
-// UpdateProduct implements ProductService
-func (s *ProductServiceV2) UpdateProduct(ctx context.Context, id string, requestDTO dto.ProductRequestDTO) error {
+// UpdateProduct implements ProductService. The availability of a stock-tracked product
+// follows its inventory, so a different requested value is rejected.
+func (s *ProductServiceV2) UpdateProduct(ctx context.Context, id string, requestDTO dto.ProductRequestDTO, expectedUpdatedAt *time.Time) error {
 	// Check if product exists
-	_, err := s.productRepo.FindByID(ctx, id)
+	existing, err := s.productRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if expectedUpdatedAt != nil && !sameVersion(existing.UpdatedAt, *expectedUpdatedAt) {
+		return repository.ErrProductModified
+	}
+
+	if err := s.validator.Validate(ctx, requestDTO, existing.SellerID); err != nil {
+		return err
+	}
 
 	// Check if brand exists and set brand name
 	if requestDTO.BrandID > 0 && requestDTO.BrandName == "" {
@@ -161,15 +248,56 @@ func (s *ProductServiceV2) UpdateProduct(ctx context.Context, id string, request
 		}
 	}
 
+	availability, err := stockAvailability(ctx, s.inventoryRepo, []int{existing.ProductID})
+	if err != nil {
+		return err
+	}
+	if available, tracked := availability[existing.ProductID]; tracked && requestDTO.Availability != available {
+		return ErrAvailabilityTracked
+	}
+
 	// Update product fields
 	product := dto.MapDTOToProduct(&requestDTO, id)
 
-	return s.productRepo.Update(ctx, id, product)
+	return s.productRepo.Update(ctx, id, product, expectedUpdatedAt)
 }
 
 // DeleteProduct implements ProductService
-func (s *ProductServiceV2) DeleteProduct(ctx context.Context, id string) error {
-	return s.productRepo.Delete(ctx, id)
+func (s *ProductServiceV2) DeleteProduct(ctx context.Context, id string, deletedBy int) error {
+	return s.productRepo.Delete(ctx, id, deletedBy)
+}
+
+// RestoreProduct implements ProductService
+func (s *ProductServiceV2) RestoreProduct(ctx context.Context, id string) (*dto.ProductResponseDTO, error) {
+	product, err := s.productRepo.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapProductWithRating(ctx, product)
+}
+
+// PurgeDeletedProducts implements ProductService
+func (s *ProductServiceV2) PurgeDeletedProducts(ctx context.Context, retention time.Duration) (int, error) {
+	productIDs, err := s.productRepo.PurgeDeleted(ctx, time.Now().Add(-retention))
+	if err != nil || len(productIDs) == 0 {
+		return 0, err
+	}
+
+	// Reviews are keyed by product_id and would otherwise be orphaned. Products stored
+	// without a product_id share 0 and own no reviews.
+	var reviewedProductIDs []int
+	for _, productID := range productIDs {
+		if productID > 0 {
+			reviewedProductIDs = append(reviewedProductIDs, productID)
+		}
+	}
+	if len(reviewedProductIDs) > 0 {
+		if _, err := s.reviewDAO.DeleteReviewsByProductIDs(ctx, reviewedProductIDs); err != nil {
+			return len(productIDs), err
+		}
+	}
+
+	return len(productIDs), nil
 }
 
 // SearchProducts implements ProductService
@@ -178,5 +306,128 @@ func (s *ProductServiceV2) SearchProducts(ctx context.Context, term string) ([]d
 	if err != nil {
 		return nil, err
 	}
-	return dto.MapProductsToDTOs(products), nil
+	return s.mapProductsWithRatings(ctx, products)
+}
+
+// SearchProductsRanked implements ProductService
+func (s *ProductServiceV2) SearchProductsRanked(ctx context.Context, filter dto.ProductFilter) (*dto.PaginatedResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+
+	scoredProducts, total, err := s.productRepo.TextSearch(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	productDTOs := make([]dto.ProductResponseDTO, len(scoredProducts))
+	for i := range scoredProducts {
+		productDTOs[i] = *dto.MapProductToDTO(&scoredProducts[i].ProductDetails)
+		productDTOs[i].Score = scoredProducts[i].Score
+	}
+	if err := s.attachRatingSummaries(ctx, productDTOs); err != nil {
+		return nil, err
+	}
+
+	return dto.CreatePaginatedResponse(productDTOs, total, filter.Page, filter.Limit), nil
+}
+
+// SearchProductsFaceted implements ProductService
+func (s *ProductServiceV2) SearchProductsFaceted(ctx context.Context, filter dto.ProductFilter, priceBoundaries []float64) (*dto.FacetedSearchResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+	if len(priceBoundaries) == 0 {
+		priceBoundaries = DefaultPriceBuckets
+	}
+
+	facets, err := s.productRepo.FacetSearch(ctx, filter, priceBoundaries)
+	if err != nil {
+		return nil, err
+	}
+
+	productDTOs, err := s.mapProductsWithRatings(ctx, facets.Products)
+	if err != nil {
+		return nil, err
+	}
+
+	priceBuckets := make([]dto.PriceBucketDTO, len(facets.PriceBuckets))
+	for i, bucket := range facets.PriceBuckets {
+		priceBuckets[i] = dto.PriceBucketDTO{Min: bucket.Min, Max: bucket.Max, Count: bucket.Count}
+	}
+
+	return &dto.FacetedSearchResponse{
+		PaginatedResponse: *dto.CreatePaginatedResponse(productDTOs, facets.Total, filter.Page, filter.Limit),
+		Facets: dto.SearchFacetsDTO{
+			Categories:   mapFacetCounts(facets.Categories),
+			Brands:       mapFacetCounts(facets.Brands),
+			Availability: mapFacetCounts(facets.Availability),
+			PriceBuckets: priceBuckets,
+		},
+	}, nil
+}
+
+// mapFacetCounts converts repository facet counts to response DTOs
+func mapFacetCounts(counts []repository.FacetCount) []dto.FacetValueDTO {
+	values := make([]dto.FacetValueDTO, len(counts))
+	for i, count := range counts {
+		values[i] = dto.FacetValueDTO{Value: count.Value, Count: count.Count}
+	}
+	return values
+}
+
+// mapProductWithRating converts a product to its response DTO including its rating summary
+func (s *ProductServiceV2) mapProductWithRating(ctx context.Context, product *mongomodels.ProductDetails) (*dto.ProductResponseDTO, error) {
+	productDTOs := []dto.ProductResponseDTO{*dto.MapProductToDTO(product)}
+	if err := s.attachRatingSummaries(ctx, productDTOs); err != nil {
+		return nil, err
+	}
+	return &productDTOs[0], nil
+}
+
+// mapProductsWithRatings converts products to response DTOs including their rating summaries
+func (s *ProductServiceV2) mapProductsWithRatings(ctx context.Context, products []mongomodels.ProductDetails) ([]dto.ProductResponseDTO, error) {
+	productDTOs := dto.MapProductsToDTOs(products)
+	if err := s.attachRatingSummaries(ctx, productDTOs); err != nil {
+		return nil, err
+	}
+	return productDTOs, nil
+}
+
+// attachRatingSummaries fills in the rating summary of every product using a single review aggregation
+func (s *ProductServiceV2) attachRatingSummaries(ctx context.Context, productDTOs []dto.ProductResponseDTO) error {
+	productIDs := make([]int, 0, len(productDTOs))
+	for _, productDTO := range productDTOs {
+		productIDs = append(productIDs, productDTO.ProductID)
+	}
+
+	summaries, err := s.reviewDAO.GetRatingSummariesForProducts(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range productDTOs {
+		rating := dto.RatingSummaryDTO{Histogram: make(map[int]int64, MaxReviewRating)}
+		for star := MinReviewRating; star <= MaxReviewRating; star++ {
+			rating.Histogram[star] = 0
+		}
+
+		if summary, ok := summaries[productDTOs[i].ProductID]; ok {
+			rating.AverageRating = summary.AverageRating
+			rating.ReviewCount = summary.ReviewCount
+			for star, count := range summary.Histogram {
+				rating.Histogram[star] = count
+			}
+		}
+
+		productDTOs[i].Rating = rating
+	}
+
+	return nil
 }
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"strings"
+	"time"
+
+	pgmodels "petopia-be/models/postgres"
+)
+
+// MaxDeliveryPartnerLength matches the assigned_delivery_partner column
+const MaxDeliveryPartnerLength = 255
+
+// Shipping errors
+var (
+	ErrNothingToShip  = apperrors.Conflict("every product of the order is already delivered or shipped")
+	ErrItemDelivered  = apperrors.Conflict("ordered product is already delivered")
+	ErrInvalidPartner = apperrors.Validation("shipment validation failed", map[string]string{
+		"delivery_partner": "must be between 1 and 255 characters",
+	})
+)
+
+// trackingStatuses lists the statuses a tracking event may have
+var trackingStatuses = []string{
+	pgmodels.TrackingStatusPickedUp,
+	pgmodels.TrackingStatusInTransit,
+	pgmodels.TrackingStatusOutForDelivery,
+	pgmodels.TrackingStatusDeliveryFailed,
+	pgmodels.TrackingStatusDelivered,
+}
+
+// ShippingServiceImpl implements ShippingService
+type ShippingServiceImpl struct {
+	shippingRepo repository.ShippingRepository
+	orderRepo    repository.OrderRepository
+}
+
+// NewShippingService creates a new ShippingService
+func NewShippingService(shippingRepo repository.ShippingRepository, orderRepo repository.OrderRepository) ShippingService {
+	return &ShippingServiceImpl{
+		shippingRepo: shippingRepo,
+		orderRepo:    orderRepo,
+	}
+}
+
+// CreateShipments implements ShippingService. Products that are delivered or already have a
+// shipment cannot be shipped again; naming one in ItemIDs fails the whole request.
+func (s *ShippingServiceImpl) CreateShipments(ctx context.Context, userID, orderID int, requestDTO dto.ShipmentRequestDTO, actorID int) ([]dto.ShipmentResponseDTO, error) {
+	var partner *string
+	if name := strings.TrimSpace(requestDTO.DeliveryPartner); name != "" {
+		if len(name) > MaxDeliveryPartnerLength {
+			return nil, ErrInvalidPartner
+		}
+		partner = &name
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipped, err := s.shippedItems(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []pgmodels.OrderedProduct
+	if len(requestDTO.ItemIDs) == 0 {
+		for _, item := range order.Items {
+			if item.DeliveryStatus == pgmodels.DeliveryStatusNotDelivered && !shipped[item.ID] {
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
+			return nil, ErrNothingToShip
+		}
+	} else {
+		for _, itemID := range requestDTO.ItemIDs {
+			item := findOrderItem(order, itemID)
+			switch {
+			case item == nil:
+				return nil, repository.ErrOrderItemNotFound
+			case item.DeliveryStatus == pgmodels.DeliveryStatusDelivered:
+				return nil, ErrItemDelivered
+			case shipped[item.ID]:
+				return nil, repository.ErrShipmentExists
+			}
+			shipped[item.ID] = true
+			items = append(items, *item)
+		}
+	}
+
+	createdBy := pgmodels.ActorRef(ownerActor(actorID, userID))
+	shipments := make([]*pgmodels.Shipment, len(items))
+	for i, item := range items {
+		itemID := item.ID
+		shipment := &pgmodels.Shipment{
+			UserID:                  userID,
+			ProductID:               item.ProductID,
+			OrderedProductID:        &itemID,
+			Address:                 order.Address,
+			AssignedDeliveryPartner: partner,
+		}
+		shipment.CreatedBy = createdBy
+		shipments[i] = shipment
+	}
+
+	if err := s.shippingRepo.CreateShipments(ctx, shipments); err != nil {
+		return nil, err
+	}
+
+	shipmentDTOs := make([]dto.ShipmentResponseDTO, len(shipments))
+	for i, shipment := range shipments {
+		shipmentDTOs[i] = *dto.MapShipmentToDTO(shipment)
+	}
+	return shipmentDTOs, nil
+}
+
+// ListShipments implements ShippingService
+func (s *ShippingServiceImpl) ListShipments(ctx context.Context, userID, orderID int) ([]dto.ShipmentResponseDTO, error) {
+	order, err := s.orderRepo.FindByID(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments, err := s.shippingRepo.ListByOrderedProducts(ctx, orderItemIDs(order))
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapShipmentsToDTOs(shipments), nil
+}
+
+// GetShipment implements ShippingService
+func (s *ShippingServiceImpl) GetShipment(ctx context.Context, id int) (*dto.ShipmentResponseDTO, error) {
+	shipment, err := s.shippingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapShipmentToDTO(shipment), nil
+}
+
+// AssignPartner implements ShippingService
+func (s *ShippingServiceImpl) AssignPartner(ctx context.Context, id int, requestDTO dto.DeliveryPartnerRequestDTO, actorID int) (*dto.ShipmentResponseDTO, error) {
+	partner := strings.TrimSpace(requestDTO.DeliveryPartner)
+	if partner == "" || len(partner) > MaxDeliveryPartnerLength {
+		return nil, ErrInvalidPartner
+	}
+
+	if err := s.shippingRepo.AssignPartner(ctx, id, partner, actorID); err != nil {
+		return nil, err
+	}
+	return s.GetShipment(ctx, id)
+}
+
+// AddTrackingEvent implements ShippingService
+func (s *ShippingServiceImpl) AddTrackingEvent(ctx context.Context, id int, requestDTO dto.TrackingEventRequestDTO, actorID int) (*dto.ShipmentTrackingResponseDTO, error) {
+	now := time.Now()
+	event := pgmodels.TrackingEvent{
+		Status:     strings.TrimSpace(requestDTO.Status),
+		Location:   strings.TrimSpace(requestDTO.Location),
+		Note:       strings.TrimSpace(requestDTO.Note),
+		OccurredAt: now,
+		RecordedAt: now,
+		RecordedBy: pgmodels.ActorRef(actorID),
+	}
+	if requestDTO.OccurredAt != nil {
+		event.OccurredAt = *requestDTO.OccurredAt
+	}
+
+	fieldErrors := make(map[string]string)
+	if !containsStatus(trackingStatuses, event.Status) {
+		fieldErrors["status"] = "must be one of " + strings.Join(trackingStatuses, ", ")
+	}
+	if event.Location == "" {
+		fieldErrors["location"] = "is required"
+	}
+	if event.OccurredAt.After(now) {
+		fieldErrors["occurred_at"] = "must not be in the future"
+	}
+	if len(fieldErrors) > 0 {
+		return nil, apperrors.Validation("tracking event validation failed", fieldErrors)
+	}
+
+	shipment, err := s.shippingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.Delivered() {
+		return nil, repository.ErrShipmentDelivered
+	}
+
+	// Delivering the shipment must be a legal move of the ordered product's delivery status
+	if event.Status == pgmodels.TrackingStatusDelivered {
+		if shipment.OrderedProductID == nil {
+			return nil, repository.ErrShipmentUnlinked
+		}
+		item, err := s.orderRepo.FindItem(ctx, *shipment.OrderedProductID)
+		if err != nil {
+			return nil, err
+		}
+		if err := deliveryStatusMachine.check(item.DeliveryStatus, pgmodels.DeliveryStatusDelivered); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.shippingRepo.AppendEvent(ctx, id, event, actorID); err != nil {
+		return nil, err
+	}
+	return s.GetTracking(ctx, id)
+}
+
+// GetTracking implements ShippingService
+func (s *ShippingServiceImpl) GetTracking(ctx context.Context, id int) (*dto.ShipmentTrackingResponseDTO, error) {
+	shipment, err := s.shippingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MapShipmentTrackingToDTO(shipment), nil
+}
+
+// shippedItems returns the IDs of the order's products that already have a shipment
+func (s *ShippingServiceImpl) shippedItems(ctx context.Context, order *pgmodels.Order) (map[int]bool, error) {
+	shipments, err := s.shippingRepo.ListByOrderedProducts(ctx, orderItemIDs(order))
+	if err != nil {
+		return nil, err
+	}
+
+	shipped := make(map[int]bool, len(shipments))
+	for _, shipment := range shipments {
+		if shipment.OrderedProductID != nil {
+			shipped[*shipment.OrderedProductID] = true
+		}
+	}
+	return shipped, nil
+}
+
+// orderItemIDs returns the IDs of an order's products
+func orderItemIDs(order *pgmodels.Order) []int {
+	ids := make([]int, len(order.Items))
+	for i, item := range order.Items {
+		ids[i] = item.ID
+	}
+	return ids
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"petopia-be/repository"
+	"regexp"
+	"strings"
+
+	pgmodels "petopia-be/models/postgres"
+)
+
+// MaxUserNameLength matches the user_accounts.name column
+const MaxUserNameLength = 255
+
+// pincodePattern matches a six digit Indian postal PIN code, which never starts with 0
+var pincodePattern = regexp.MustCompile(`^[1-9][0-9]{5}$`)
+
+// UserServiceImpl implements UserService
+type UserServiceImpl struct {
+	userRepo repository.UserRepository
+}
+
+// NewUserService creates a new UserService
+func NewUserService(userRepo repository.UserRepository) UserService {
+	return &UserServiceImpl{
+		userRepo: userRepo,
+	}
+}
+
+// CreateUser implements UserService
+func (s *UserServiceImpl) CreateUser(ctx context.Context, requestDTO dto.UserRequestDTO, actorID int) (*dto.UserResponseDTO, error) {
+	name, err := validateUserName(requestDTO.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &pgmodels.UserAccount{Name: name}
+	user.CreatedBy = pgmodels.ActorRef(actorID)
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return dto.MapUserToDTO(user, nil), nil
+}
+
+// GetUser implements UserService
+func (s *UserServiceImpl) GetUser(ctx context.Context, id int) (*dto.UserResponseDTO, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.userRepo.ListAddresses(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.MapUserToDTO(user, addresses), nil
+}
+
+// UpdateUser implements UserService
+func (s *UserServiceImpl) UpdateUser(ctx context.Context, id int, requestDTO dto.UserRequestDTO, actorID int) (*dto.UserResponseDTO, error) {
+	name, err := validateUserName(requestDTO.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Name = name
+	if err := s.userRepo.Update(ctx, user, actorID); err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.userRepo.ListAddresses(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.MapUserToDTO(user, addresses), nil
+}
+
+// DeleteUser implements UserService
+func (s *UserServiceImpl) DeleteUser(ctx context.Context, id int, actorID int) error {
+	return s.userRepo.SoftDelete(ctx, id, actorID)
+}
+
+// ListAddresses implements UserService
+func (s *UserServiceImpl) ListAddresses(ctx context.Context, userID int) ([]dto.AddressResponseDTO, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.userRepo.ListAddresses(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.MapAddressesToDTOs(addresses), nil
+}
+
+// AddAddress implements UserService
+func (s *UserServiceImpl) AddAddress(ctx context.Context, userID int, requestDTO dto.AddressRequestDTO, actorID int) (*dto.AddressResponseDTO, error) {
+	address, err := validateAddress(requestDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	address.UserID = userID
+	address.CreatedBy = pgmodels.ActorRef(ownerActor(actorID, userID))
+	if err := s.userRepo.CreateAddress(ctx, address); err != nil {
+		return nil, err
+	}
+
+	return dto.MapAddressToDTO(address), nil
+}
+
+// UpdateAddress implements UserService
+func (s *UserServiceImpl) UpdateAddress(ctx context.Context, userID, addressID int, requestDTO dto.AddressRequestDTO, actorID int) (*dto.AddressResponseDTO, error) {
+	validated, err := validateAddress(requestDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := s.userRepo.FindAddress(ctx, userID, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	address.Address = validated.Address
+	address.Pincode = validated.Pincode
+	if err := s.userRepo.UpdateAddress(ctx, address, actorID); err != nil {
+		return nil, err
+	}
+
+	return dto.MapAddressToDTO(address), nil
+}
+
+// DeleteAddress implements UserService
+func (s *UserServiceImpl) DeleteAddress(ctx context.Context, userID, addressID int, actorID int) error {
+	return s.userRepo.SoftDeleteAddress(ctx, userID, addressID, actorID)
+}
+
+// ownerActor returns the acting user, falling back to the owner of the row for requests
+// without a known actor. Tables whose created_by is required use it, so a user acting on
+// their own data is recorded as its creator.
+func ownerActor(actorID, ownerID int) int {
+	if actorID <= 0 {
+		return ownerID
+	}
+	return actorID
+}
+
+// validateUserName trims a user name and checks it against the column constraints
+func validateUserName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return "", apperrors.Validation("user validation failed", map[string]string{"name": "is required"})
+	case len(name) > MaxUserNameLength:
+		return "", apperrors.Validation("user validation failed", map[string]string{"name": "must be at most 255 characters"})
+	}
+	return name, nil
+}
+
+// validateAddress checks an address request and returns the address with a normalized pincode.
+// Spaces inside the pincode are dropped, so "560 001" is stored as "560001".
+func validateAddress(requestDTO dto.AddressRequestDTO) (*pgmodels.UserAddress, error) {
+	fieldErrors := make(map[string]string)
+
+	address := strings.TrimSpace(requestDTO.Address)
+	if address == "" {
+		fieldErrors["address"] = "is required"
+	}
+
+	pincode := strings.ReplaceAll(strings.TrimSpace(requestDTO.Pincode), " ", "")
+	if pincode == "" {
+		fieldErrors["pincode"] = "is required"
+	} else if !pincodePattern.MatchString(pincode) {
+		fieldErrors["pincode"] = "must be a 6 digit PIN code not starting with 0"
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, apperrors.Validation("address validation failed", fieldErrors)
+	}
+	return &pgmodels.UserAddress{Address: address, Pincode: pincode}, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"errors"
+	"petopia-be/apperrors"
+	"petopia-be/dto"
+	"reflect"
+	"testing"
+)
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		name           string
+		address        string
+		pincode        string
+		wantAddress    string
+		wantPincode    string
+		wantFieldError map[string]string
+	}{
+		{name: "valid", address: "12 MG Road, Bengaluru", pincode: "560001", wantAddress: "12 MG Road, Bengaluru", wantPincode: "560001"},
+		{name: "spaces are dropped", address: " 12 MG Road ", pincode: " 560 001 ", wantAddress: "12 MG Road", wantPincode: "560001"},
+		{name: "leading zero", address: "12 MG Road", pincode: "060001",
+			wantFieldError: map[string]string{"pincode": "must be a 6 digit PIN code not starting with 0"}},
+		{name: "too short", address: "12 MG Road", pincode: "56001",
+			wantFieldError: map[string]string{"pincode": "must be a 6 digit PIN code not starting with 0"}},
+		{name: "too long", address: "12 MG Road", pincode: "5600011",
+			wantFieldError: map[string]string{"pincode": "must be a 6 digit PIN code not starting with 0"}},
+		{name: "letters", address: "12 MG Road", pincode: "56OO01",
+			wantFieldError: map[string]string{"pincode": "must be a 6 digit PIN code not starting with 0"}},
+		{name: "hyphenated", address: "12 MG Road", pincode: "560-001",
+			wantFieldError: map[string]string{"pincode": "must be a 6 digit PIN code not starting with 0"}},
+		{name: "missing pincode", address: "12 MG Road", pincode: "   ",
+			wantFieldError: map[string]string{"pincode": "is required"}},
+		{name: "missing address and pincode", address: " ", pincode: "",
+			wantFieldError: map[string]string{"address": "is required", "pincode": "is required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateAddress(dto.AddressRequestDTO{Address: tt.address, Pincode: tt.pincode})
+			if tt.wantFieldError != nil {
+				if !errors.Is(err, apperrors.ErrValidation) {
+					t.Fatalf("validateAddress() error = %v, want a validation error", err)
+				}
+				if details := apperrors.DetailsOf(err); !reflect.DeepEqual(details, tt.wantFieldError) {
+					t.Errorf("validateAddress() details = %v, want %v", details, tt.wantFieldError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateAddress() error = %v", err)
+			}
+			if got.Address != tt.wantAddress || got.Pincode != tt.wantPincode {
+				t.Errorf("validateAddress() = (%q, %q), want (%q, %q)", got.Address, got.Pincode, tt.wantAddress, tt.wantPincode)
+			}
+		})
+	}
+}